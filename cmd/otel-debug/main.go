@@ -0,0 +1,57 @@
+// Command otel-debug is a local OTLP trace receiver for development. It listens on the
+// default OTLP ports and prints received spans as trees:
+//
+//	go run github.com/cristiano-pacheco/go-otel/cmd/otel-debug -attributes -out traces.jsonl
+//
+// Point the tracer at it with TraceEnabled: true, Insecure: true and TraceURL
+// "localhost:4317" (gRPC) or "localhost:4318" (HTTP).
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cristiano-pacheco/go-otel/trace/otlpdebug"
+)
+
+const outputFileMode = 0o644
+
+func main() {
+	grpcAddr := flag.String("grpc", otlpdebug.DefaultGRPCAddr, "OTLP/gRPC listen address, empty to disable")
+	httpAddr := flag.String("http", otlpdebug.DefaultHTTPAddr, "OTLP/HTTP listen address, empty to disable")
+	out := flag.String("out", "", "append received requests as OTLP JSON lines to this file")
+	attributes := flag.Bool("attributes", false, "print span attributes and events")
+	flag.Parse()
+
+	if err := run(*grpcAddr, *httpAddr, *out, *attributes); err != nil {
+		log.Fatalf("Receiver stopped: %v", err)
+	}
+}
+
+func run(grpcAddr, httpAddr, out string, attributes bool) error {
+	config := otlpdebug.Config{
+		GRPCAddr:   grpcAddr,
+		HTTPAddr:   httpAddr,
+		Output:     os.Stdout,
+		Attributes: attributes,
+	}
+
+	if out != "" {
+		file, err := os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_APPEND, outputFileMode)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		config.File = file
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Receiving OTLP traces on gRPC %q and HTTP %q", grpcAddr, httpAddr)
+	return otlpdebug.NewReceiver(config).ListenAndServe(ctx)
+}
@@ -0,0 +1,99 @@
+// Command otel-replay sends spans captured as OTLP JSON lines, e.g. by otel-debug -out or
+// the collector's file exporter, to an OTLP endpoint. Files ending in .gz are decompressed
+// and standard input is read when no file is given:
+//
+//	go run github.com/cristiano-pacheco/go-otel/cmd/otel-replay -endpoint collector:4317 traces.jsonl
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/cristiano-pacheco/go-otel/trace/otlpreplay"
+)
+
+// headerFlags collects repeated -header name=value flags.
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	return fmt.Sprint(map[string]string(h))
+}
+
+func (h headerFlags) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("header %q is not name=value", value)
+	}
+	h[name] = val
+	return nil
+}
+
+func main() {
+	headers := headerFlags{}
+	config := otlpreplay.Config{}
+	flag.StringVar(&config.Endpoint, "endpoint", "localhost:4317", "OTLP endpoint host:port")
+	flag.BoolVar(&config.HTTP, "http", false, "use OTLP/HTTP instead of OTLP/gRPC")
+	flag.BoolVar(&config.Insecure, "insecure", false, "disable TLS")
+	flag.StringVar(&config.URLPath, "path", "", "OTLP/HTTP traces path, default /v1/traces")
+	flag.Var(headers, "header", "header sent with every request as name=value, repeatable")
+	flag.Parse()
+	config.Headers = headers
+
+	if err := run(config, flag.Args()); err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+}
+
+func run(config otlpreplay.Config, files []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	replayer, err := otlpreplay.NewReplayer(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer replayer.Close(context.Background())
+
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		stats, err := replayFile(ctx, replayer, name)
+		log.Printf("%s: replayed %d spans in %d requests", name, stats.Spans, stats.Requests)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func replayFile(ctx context.Context, replayer *otlpreplay.Replayer, name string) (otlpreplay.Stats, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return otlpreplay.Stats{}, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return otlpreplay.Stats{}, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	return replayer.Replay(ctx, r)
+}
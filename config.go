@@ -0,0 +1,38 @@
+package otel
+
+import (
+	"github.com/cristiano-pacheco/go-otel/logs"
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"github.com/cristiano-pacheco/go-otel/trace"
+)
+
+// Config configures traces, metrics, and logs at once. AppName, AppVersion and
+// ServiceInstanceID are shared by the three signals and override the values in the
+// per-signal configurations.
+type Config struct {
+	AppName           string
+	AppVersion        string
+	ServiceInstanceID string
+	Trace             trace.TracerConfig
+	Metric            metric.MeterConfig
+	Log               logs.LoggerConfig
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	if c.AppName == "" {
+		return ErrAppNameRequired
+	}
+	return nil
+}
+
+// applyServiceInfo copies the shared service information into the signal configurations
+func (c *Config) applyServiceInfo() {
+	c.Trace.AppName = c.AppName
+	c.Trace.AppVersion = c.AppVersion
+	c.Trace.ServiceInstanceID = c.ServiceInstanceID
+	c.Metric.AppName = c.AppName
+	c.Metric.AppVersion = c.AppVersion
+	c.Log.AppName = c.AppName
+	c.Log.AppVersion = c.AppVersion
+}
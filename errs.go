@@ -0,0 +1,11 @@
+package otel
+
+import "errors"
+
+var (
+	ErrAppNameRequired = errors.New("AppName is required")
+
+	ErrInitializeTrace  = errors.New("failed to initialize traces")
+	ErrInitializeMetric = errors.New("failed to initialize metrics")
+	ErrInitializeLog    = errors.New("failed to initialize logs")
+)
@@ -0,0 +1,53 @@
+package logs
+
+import (
+	"time"
+)
+
+const (
+	defaultExportInterval = 1 * time.Second
+	defaultExportTimeout  = 30 * time.Second
+	defaultMaxBatchSize   = 512
+)
+
+type LoggerConfig struct {
+	AppName        string
+	AppVersion     string
+	LogURL         string
+	LogsEnabled    bool
+	ExportInterval time.Duration // maximum delay between exports, default 1s
+	ExportTimeout  time.Duration // timeout of a single export, default 30s
+	MaxBatchSize   int           // default 512
+	Insecure       bool
+	ExporterType   ExporterType // GRPC or HTTP, default GRPC
+}
+
+// Validate checks if the configuration is valid
+func (c *LoggerConfig) Validate() error {
+	if c.AppName == "" {
+		return ErrAppNameRequired
+	}
+	if c.LogsEnabled && c.LogURL == "" {
+		return ErrLogURLRequired
+	}
+	return nil
+}
+
+// setDefaults sets default values for optional configuration fields
+func (c *LoggerConfig) setDefaults() {
+	if c.ExportInterval == 0 {
+		c.ExportInterval = defaultExportInterval
+	}
+	if c.ExportTimeout == 0 {
+		c.ExportTimeout = defaultExportTimeout
+	}
+	if c.MaxBatchSize == 0 {
+		c.MaxBatchSize = defaultMaxBatchSize
+	}
+	if c.ExporterType.IsZero() {
+		exporterType, err := NewExporterType(ExporterTypeGRPC)
+		if err == nil {
+			c.ExporterType = exporterType
+		}
+	}
+}
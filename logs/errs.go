@@ -0,0 +1,16 @@
+package logs
+
+import "errors"
+
+var (
+	ErrAppNameRequired     = errors.New("AppName is required")
+	ErrLogURLRequired      = errors.New("LogURL is required when logs are enabled")
+	ErrInvalidExporterType = errors.New("invalid exporter type (must be 'grpc' or 'http')")
+
+	ErrAlreadyInitialized     = errors.New("logger already initialized")
+	ErrNotInitialized         = errors.New("logger not initialized")
+	ErrCreateExporter         = errors.New("failed to create exporter")
+	ErrCreateGRPCExporter     = errors.New("failed to create OTLP gRPC log exporter")
+	ErrCreateHTTPExporter     = errors.New("failed to create OTLP HTTP log exporter")
+	ErrLoggerProviderShutdown = errors.New("logger provider shutdown failed")
+)
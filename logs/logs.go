@@ -0,0 +1,179 @@
+// Package logs provides a global, function-based API for OpenTelemetry logs,
+// mirroring the trace package. Records are emitted through an slog.Handler bridge.
+package logs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/log/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+var (
+	globalAppName        string
+	globalLoggerProvider *sdklog.LoggerProvider
+	globalMutex          sync.RWMutex
+	initialized          bool
+)
+
+// Initialize configures the global logger provider.
+// Returns an error if initialization fails.
+func Initialize(config LoggerConfig) error {
+	return InitializeWithResource(config, nil)
+}
+
+// InitializeWithResource is like Initialize but uses the given resource instead of
+// building one from the configuration. A nil resource behaves like Initialize.
+func InitializeWithResource(config LoggerConfig, res *resource.Resource) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if initialized {
+		return ErrAlreadyInitialized
+	}
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config.setDefaults()
+
+	if res == nil {
+		res = createResource(config)
+	}
+
+	lp, err := newLoggerProvider(config, res)
+	if err != nil {
+		return err
+	}
+
+	global.SetLoggerProvider(lp)
+
+	globalAppName = config.AppName
+	globalLoggerProvider = lp
+	initialized = true
+
+	return nil
+}
+
+// MustInitialize initializes the global logger provider and panics if it fails.
+func MustInitialize(config LoggerConfig) {
+	if err := Initialize(config); err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+}
+
+// createResource creates the OpenTelemetry resource describing the service
+func createResource(config LoggerConfig) *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.AppName),
+		semconv.ServiceVersion(config.AppVersion),
+	)
+}
+
+// newLoggerProvider creates a logger provider with a batch processor, or without
+// processors when logs are disabled so records are dropped.
+func newLoggerProvider(config LoggerConfig, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	if !config.LogsEnabled {
+		return sdklog.NewLoggerProvider(sdklog.WithResource(res)), nil
+	}
+
+	exp, err := newExporter(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateExporter, err)
+	}
+
+	processor := sdklog.NewBatchProcessor(exp,
+		sdklog.WithExportInterval(config.ExportInterval),
+		sdklog.WithExportTimeout(config.ExportTimeout),
+		sdklog.WithExportMaxBatchSize(config.MaxBatchSize),
+	)
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(processor),
+	), nil
+}
+
+// newExporter creates a new OTLP log exporter (gRPC or HTTP based on config)
+func newExporter(config LoggerConfig) (sdklog.Exporter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.ExportTimeout)
+	defer cancel()
+
+	if config.ExporterType.IsGRPC() {
+		options := []otlploggrpc.Option{otlploggrpc.WithEndpoint(config.LogURL)}
+		if config.Insecure {
+			options = append(options, otlploggrpc.WithInsecure())
+		}
+		exporter, err := otlploggrpc.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCreateGRPCExporter, err)
+		}
+		return exporter, nil
+	}
+
+	if config.ExporterType.IsHTTP() {
+		options := []otlploghttp.Option{otlploghttp.WithEndpoint(config.LogURL)}
+		if config.Insecure {
+			options = append(options, otlploghttp.WithInsecure())
+		}
+		exporter, err := otlploghttp.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCreateHTTPExporter, err)
+		}
+		return exporter, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInvalidExporterType, config.ExporterType.String())
+}
+
+// Handler returns an slog.Handler that emits records through the global logger provider,
+// correlated with the span in the record's context. Before Initialize records are dropped.
+func Handler() slog.Handler {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized || globalLoggerProvider == nil {
+		return otelslog.NewHandler("", otelslog.WithLoggerProvider(noop.NewLoggerProvider()))
+	}
+	return otelslog.NewHandler(globalAppName, otelslog.WithLoggerProvider(globalLoggerProvider))
+}
+
+// Shutdown flushes pending records and shuts down the logger provider.
+// Should be called during application shutdown.
+func Shutdown(ctx context.Context) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+
+	var shutdownErr error
+	if err := globalLoggerProvider.Shutdown(ctx); err != nil {
+		shutdownErr = fmt.Errorf("%w: %w", ErrLoggerProviderShutdown, err)
+	}
+
+	globalAppName = ""
+	globalLoggerProvider = nil
+	initialized = false
+
+	return shutdownErr
+}
+
+// IsInitialized returns true if the logger provider has been initialized.
+func IsInitialized() bool {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return initialized
+}
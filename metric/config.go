@@ -0,0 +1,48 @@
+package metric
+
+import (
+	"time"
+)
+
+const (
+	defaultExportInterval = 60 * time.Second
+	defaultExportTimeout  = 30 * time.Second
+)
+
+type MeterConfig struct {
+	AppName        string
+	AppVersion     string
+	MetricURL      string
+	MetricsEnabled bool
+	ExportInterval time.Duration // interval between exports, default 60s
+	ExportTimeout  time.Duration // timeout of a single export, default 30s
+	Insecure       bool
+	ExporterType   ExporterType // GRPC or HTTP, default GRPC
+}
+
+// Validate checks if the configuration is valid
+func (c *MeterConfig) Validate() error {
+	if c.AppName == "" {
+		return ErrAppNameRequired
+	}
+	if c.MetricsEnabled && c.MetricURL == "" {
+		return ErrMetricURLRequired
+	}
+	return nil
+}
+
+// setDefaults sets default values for optional configuration fields
+func (c *MeterConfig) setDefaults() {
+	if c.ExportInterval == 0 {
+		c.ExportInterval = defaultExportInterval
+	}
+	if c.ExportTimeout == 0 {
+		c.ExportTimeout = defaultExportTimeout
+	}
+	if c.ExporterType.IsZero() {
+		exporterType, err := NewExporterType(ExporterTypeGRPC)
+		if err == nil {
+			c.ExporterType = exporterType
+		}
+	}
+}
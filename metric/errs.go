@@ -0,0 +1,17 @@
+package metric
+
+import "errors"
+
+var (
+	ErrAppNameRequired     = errors.New("AppName is required")
+	ErrMetricURLRequired   = errors.New("MetricURL is required when metrics are enabled")
+	ErrInvalidExporterType = errors.New("invalid exporter type (must be 'grpc' or 'http')")
+
+	ErrAlreadyInitialized    = errors.New("meter already initialized")
+	ErrNotInitialized        = errors.New("meter not initialized")
+	ErrCreateExporter        = errors.New("failed to create exporter")
+	ErrCreateGRPCExporter    = errors.New("failed to create OTLP gRPC metric exporter")
+	ErrCreateHTTPExporter    = errors.New("failed to create OTLP HTTP metric exporter")
+	ErrMeterProviderFlush    = errors.New("meter provider flush failed")
+	ErrMeterProviderShutdown = errors.New("meter provider shutdown failed")
+)
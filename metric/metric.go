@@ -0,0 +1,196 @@
+// Package metric provides a global, function-based API for OpenTelemetry metrics,
+// mirroring the trace package: initialize once at startup and use Meter anywhere.
+package metric
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+var (
+	globalMeter         otelmetric.Meter
+	globalMeterProvider *sdkmetric.MeterProvider
+	globalMutex         sync.RWMutex
+	initialized         bool
+)
+
+// Initialize configures the global meter provider.
+// Returns an error if initialization fails.
+func Initialize(config MeterConfig) error {
+	return InitializeWithResource(config, nil)
+}
+
+// InitializeWithResource is like Initialize but uses the given resource instead of
+// building one from the configuration. A nil resource behaves like Initialize.
+func InitializeWithResource(config MeterConfig, res *resource.Resource) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if initialized {
+		return ErrAlreadyInitialized
+	}
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config.setDefaults()
+
+	if res == nil {
+		res = createResource(config)
+	}
+
+	mp, err := newMeterProvider(config, res)
+	if err != nil {
+		return err
+	}
+
+	otel.SetMeterProvider(mp)
+
+	globalMeter = mp.Meter(config.AppName)
+	globalMeterProvider = mp
+	initialized = true
+
+	return nil
+}
+
+// MustInitialize initializes the global meter provider and panics if it fails.
+func MustInitialize(config MeterConfig) {
+	if err := Initialize(config); err != nil {
+		panic(fmt.Sprintf("failed to initialize meter: %v", err))
+	}
+}
+
+// createResource creates the OpenTelemetry resource describing the service
+func createResource(config MeterConfig) *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.AppName),
+		semconv.ServiceVersion(config.AppVersion),
+	)
+}
+
+// newMeterProvider creates a meter provider with a periodic reader, or without
+// readers when metrics are disabled so instruments are no-ops.
+func newMeterProvider(config MeterConfig, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
+	if !config.MetricsEnabled {
+		return sdkmetric.NewMeterProvider(sdkmetric.WithResource(res)), nil
+	}
+
+	exp, err := newExporter(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateExporter, err)
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exp,
+		sdkmetric.WithInterval(config.ExportInterval),
+		sdkmetric.WithTimeout(config.ExportTimeout),
+	)
+
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(reader),
+	), nil
+}
+
+// newExporter creates a new OTLP metric exporter (gRPC or HTTP based on config)
+func newExporter(config MeterConfig) (sdkmetric.Exporter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.ExportTimeout)
+	defer cancel()
+
+	if config.ExporterType.IsGRPC() {
+		options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(config.MetricURL)}
+		if config.Insecure {
+			options = append(options, otlpmetricgrpc.WithInsecure())
+		}
+		exporter, err := otlpmetricgrpc.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCreateGRPCExporter, err)
+		}
+		return exporter, nil
+	}
+
+	if config.ExporterType.IsHTTP() {
+		options := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(config.MetricURL)}
+		if config.Insecure {
+			options = append(options, otlpmetrichttp.WithInsecure())
+		}
+		exporter, err := otlpmetrichttp.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCreateHTTPExporter, err)
+		}
+		return exporter, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInvalidExporterType, config.ExporterType.String())
+}
+
+// Meter returns the global meter. Before Initialize it returns a no-op meter.
+func Meter() otelmetric.Meter {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized || globalMeter == nil {
+		return noop.NewMeterProvider().Meter("")
+	}
+	return globalMeter
+}
+
+// ForceFlush exports all pending measurements.
+func ForceFlush(ctx context.Context) error {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+	if err := globalMeterProvider.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrMeterProviderFlush, err)
+	}
+	return nil
+}
+
+// Shutdown flushes pending measurements and shuts down the meter provider.
+// Should be called during application shutdown.
+func Shutdown(ctx context.Context) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+
+	logger := slog.Default()
+	var shutdownErr error
+
+	if err := globalMeterProvider.Shutdown(ctx); err != nil {
+		logger.ErrorContext(ctx, "Failed to shutdown meter provider", "error", err)
+		shutdownErr = fmt.Errorf("%w: %w", ErrMeterProviderShutdown, err)
+	} else {
+		logger.InfoContext(ctx, "Meter provider shutdown successfully...")
+	}
+
+	globalMeter = nil
+	globalMeterProvider = nil
+	initialized = false
+
+	return shutdownErr
+}
+
+// IsInitialized returns true if the meter provider has been initialized.
+func IsInitialized() bool {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return initialized
+}
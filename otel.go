@@ -0,0 +1,63 @@
+// Package otel initializes traces, metrics, and logs together with a shared resource
+// and shuts them down in order with a single call.
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cristiano-pacheco/go-otel/logs"
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"github.com/cristiano-pacheco/go-otel/trace"
+)
+
+// Initialize builds the resource once and initializes the trace, metric, and log packages
+// with it. If a signal fails to initialize, the already initialized ones are shut down.
+func Initialize(config Config) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config.applyServiceInfo()
+	res := trace.NewResource(config.Trace)
+
+	if err := trace.InitializeWithResource(config.Trace, res); err != nil {
+		return fmt.Errorf("%w: %w", ErrInitializeTrace, err)
+	}
+
+	if err := metric.InitializeWithResource(config.Metric, res); err != nil {
+		return errors.Join(
+			fmt.Errorf("%w: %w", ErrInitializeMetric, err),
+			trace.Shutdown(context.Background()),
+		)
+	}
+
+	if err := logs.InitializeWithResource(config.Log, res); err != nil {
+		return errors.Join(
+			fmt.Errorf("%w: %w", ErrInitializeLog, err),
+			metric.Shutdown(context.Background()),
+			trace.Shutdown(context.Background()),
+		)
+	}
+
+	return nil
+}
+
+// MustInitialize initializes all signals and panics if it fails.
+func MustInitialize(config Config) {
+	if err := Initialize(config); err != nil {
+		panic(fmt.Sprintf("failed to initialize telemetry: %v", err))
+	}
+}
+
+// Shutdown flushes and shuts down traces first, then metrics, then logs, so that
+// log records emitted while the other signals shut down are still exported.
+// All signals are shut down even if one fails; the errors are joined.
+func Shutdown(ctx context.Context) error {
+	return errors.Join(
+		trace.Shutdown(ctx),
+		metric.Shutdown(ctx),
+		logs.Shutdown(ctx),
+	)
+}
@@ -0,0 +1,198 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// maxAttrSliceLength bounds the elements kept from slices converted by Attr.
+	maxAttrSliceLength = 128
+	// maxAttrJSONSize bounds, in bytes, the JSON encoding of values converted by Attr.
+	maxAttrJSONSize = 4096
+
+	truncatedSuffix = "...(truncated)"
+)
+
+// Attr converts value to an attribute, sparing the conversion of domain types:
+//
+//   - booleans, integers, floats and strings, named types included, keep their type
+//   - time.Duration is recorded as its string form, e.g. "1.5s"
+//   - time.Time is recorded in RFC 3339 format, in UTC
+//   - UUIDs, errors and fmt.Stringer implementations are recorded as strings
+//   - slices of booleans, integers, floats and strings become slice attributes, limited
+//     to their first 128 elements
+//   - maps, structs and other slices are JSON-encoded, truncated at 4 KiB
+//
+// Nil values are recorded as an empty string, pointers are dereferenced and byte slices
+// holding valid UTF-8 are recorded as strings.
+func Attr(key string, value any) attribute.KeyValue {
+	return attribute.KeyValue{Key: attribute.Key(key), Value: attrValue(value)}
+}
+
+// SetAttributes sets an attribute per entry of attrs, converted with Attr, on the span in
+// ctx. It does nothing when the span is not recording.
+func SetAttributes(ctx context.Context, attrs map[string]any) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() || len(attrs) == 0 {
+		return
+	}
+
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, key := range slices.Sorted(maps.Keys(attrs)) {
+		kvs = append(kvs, Attr(key, attrs[key]))
+	}
+	span.SetAttributes(kvs...)
+}
+
+func attrValue(value any) attribute.Value {
+	switch v := value.(type) {
+	case nil:
+		return attribute.StringValue("")
+	case attribute.Value:
+		return v
+	case string:
+		return attribute.StringValue(v)
+	case bool:
+		return attribute.BoolValue(v)
+	case int:
+		return attribute.IntValue(v)
+	case int64:
+		return attribute.Int64Value(v)
+	case float64:
+		return attribute.Float64Value(v)
+	case time.Duration:
+		return attribute.StringValue(v.String())
+	case time.Time:
+		return attribute.StringValue(v.UTC().Format(time.RFC3339Nano))
+	case uuid.UUID:
+		return attribute.StringValue(v.String())
+	case []byte:
+		if utf8.Valid(v) {
+			return attribute.StringValue(truncateAttr(string(v)))
+		}
+		return jsonValue(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return attribute.StringValue("")
+		}
+		// methods declared on the pointer type only are lost once dereferenced
+		elem := rv.Elem().Interface()
+		if _, ok := textValue(elem); !ok {
+			if v, ok := textValue(value); ok {
+				return v
+			}
+		}
+		return attrValue(elem)
+	}
+
+	if v, ok := textValue(value); ok {
+		return v
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return attribute.StringValue(rv.String())
+	case reflect.Bool:
+		return attribute.BoolValue(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return attribute.Int64Value(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintValue(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return attribute.Float64Value(rv.Float())
+	case reflect.Slice, reflect.Array:
+		if v, ok := sliceValue(rv); ok {
+			return v
+		}
+	}
+	return jsonValue(value)
+}
+
+// textValue records errors and fmt.Stringer implementations as strings, or reports false.
+func textValue(value any) (attribute.Value, bool) {
+	switch v := value.(type) {
+	case error:
+		return attribute.StringValue(v.Error()), true
+	case fmt.Stringer:
+		return attribute.StringValue(v.String()), true
+	}
+	return attribute.Value{}, false
+}
+
+// uintValue records unsigned integers as int64, or as a string when they overflow it.
+func uintValue(u uint64) attribute.Value {
+	if u > uint64(1<<63-1) {
+		return attribute.StringValue(fmt.Sprint(u))
+	}
+	return attribute.Int64Value(int64(u))
+}
+
+// sliceValue converts slices of basic kinds to slice attributes, or reports false.
+func sliceValue(rv reflect.Value) (attribute.Value, bool) {
+	n := min(rv.Len(), maxAttrSliceLength)
+
+	switch rv.Type().Elem().Kind() {
+	case reflect.String:
+		values := make([]string, n)
+		for i := range n {
+			values[i] = rv.Index(i).String()
+		}
+		return attribute.StringSliceValue(values), true
+	case reflect.Bool:
+		values := make([]bool, n)
+		for i := range n {
+			values[i] = rv.Index(i).Bool()
+		}
+		return attribute.BoolSliceValue(values), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values := make([]int64, n)
+		for i := range n {
+			values[i] = rv.Index(i).Int()
+		}
+		return attribute.Int64SliceValue(values), true
+	case reflect.Uint16, reflect.Uint32:
+		values := make([]int64, n)
+		for i := range n {
+			values[i] = int64(rv.Index(i).Uint())
+		}
+		return attribute.Int64SliceValue(values), true
+	case reflect.Float32, reflect.Float64:
+		values := make([]float64, n)
+		for i := range n {
+			values[i] = rv.Index(i).Float()
+		}
+		return attribute.Float64SliceValue(values), true
+	}
+	return attribute.Value{}, false
+}
+
+// jsonValue records value as JSON, or with fmt when it cannot be encoded.
+func jsonValue(value any) attribute.Value {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return attribute.StringValue(truncateAttr(fmt.Sprint(value)))
+	}
+	return attribute.StringValue(truncateAttr(string(b)))
+}
+
+// truncateAttr cuts s to maxAttrJSONSize bytes without splitting a UTF-8 character.
+func truncateAttr(s string) string {
+	if len(s) <= maxAttrJSONSize {
+		return s
+	}
+	return truncateUTF8(s, maxAttrJSONSize-len(truncatedSuffix)) + truncatedSuffix
+}
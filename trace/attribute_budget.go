@@ -0,0 +1,155 @@
+package trace
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	droppedAttributesCountKey = attribute.Key("otel.attributes.dropped_count")
+	droppedAttributesKeysKey  = attribute.Key("otel.attributes.dropped_keys")
+	truncatedAttributesKey    = attribute.Key("otel.attributes.truncated_keys")
+)
+
+// AttributeBudget bounds the attributes of exported spans, see TracerConfig.AttributeBudget.
+type AttributeBudget struct {
+	// MaxAttributes is the number of attributes kept per span, 0 for no limit. Keys listed
+	// in Keep are kept first, then the other attributes in the order they were set.
+	MaxAttributes int
+	// MaxValueLength truncates string values, and each string of string slices, to this
+	// many bytes, 0 for no limit
+	MaxValueLength int
+	// Keep lists the keys kept whatever MaxAttributes, e.g. http.route or tenant.id
+	Keep []string
+}
+
+func (b AttributeBudget) enabled() bool {
+	return b.MaxAttributes > 0 || b.MaxValueLength > 0
+}
+
+// attributeBudgetProcessor enforces an AttributeBudget on the exported view of ended
+// spans, and reports what was dropped or truncated with otel.attributes.* attributes,
+// which are not counted in the budget.
+type attributeBudgetProcessor struct {
+	next   sdktrace.SpanProcessor
+	budget AttributeBudget
+	keep   map[attribute.Key]bool
+}
+
+var _ sdktrace.SpanProcessor = (*attributeBudgetProcessor)(nil)
+
+func newAttributeBudgetProcessor(next sdktrace.SpanProcessor, budget AttributeBudget) *attributeBudgetProcessor {
+	keep := make(map[attribute.Key]bool, len(budget.Keep))
+	for _, key := range budget.Keep {
+		keep[attribute.Key(key)] = true
+	}
+	return &attributeBudgetProcessor{next: next, budget: budget, keep: keep}
+}
+
+func (p *attributeBudgetProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *attributeBudgetProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if attrs, changed := p.apply(s.Attributes()); changed {
+		s = budgetedSpan{ReadOnlySpan: s, attrs: attrs}
+	}
+	p.next.OnEnd(s)
+}
+
+func (p *attributeBudgetProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *attributeBudgetProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// apply returns the attributes within the budget and whether they differ from attrs.
+func (p *attributeBudgetProcessor) apply(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	kept := attrs
+	var dropped []string
+	if limit := p.budget.MaxAttributes; limit > 0 && len(attrs) > limit {
+		kept = make([]attribute.KeyValue, 0, limit)
+		for _, kv := range attrs {
+			if p.keep[kv.Key] {
+				kept = append(kept, kv)
+			}
+		}
+		for _, kv := range attrs {
+			if p.keep[kv.Key] {
+				continue
+			}
+			if len(kept) < limit {
+				kept = append(kept, kv)
+			} else {
+				dropped = append(dropped, string(kv.Key))
+			}
+		}
+	}
+
+	var truncated []string
+	if p.budget.MaxValueLength > 0 {
+		for i, kv := range kept {
+			value, ok := truncateValue(kv.Value, p.budget.MaxValueLength)
+			if !ok {
+				continue
+			}
+			if len(truncated) == 0 && len(dropped) == 0 {
+				kept = slices.Clone(kept)
+			}
+			kept[i].Value = value
+			truncated = append(truncated, string(kv.Key))
+		}
+	}
+
+	if len(dropped) == 0 && len(truncated) == 0 {
+		return attrs, false
+	}
+	if len(dropped) > 0 {
+		kept = append(kept,
+			droppedAttributesCountKey.Int(len(dropped)),
+			droppedAttributesKeysKey.StringSlice(dropped),
+		)
+	}
+	if len(truncated) > 0 {
+		kept = append(kept, truncatedAttributesKey.StringSlice(truncated))
+	}
+	return kept, true
+}
+
+// truncateValue truncates string values to maxLength bytes, reporting whether it did.
+func truncateValue(value attribute.Value, maxLength int) (attribute.Value, bool) {
+	switch value.Type() {
+	case attribute.STRING:
+		if s := value.AsString(); len(s) > maxLength {
+			return attribute.StringValue(truncateUTF8(s, maxLength)), true
+		}
+	case attribute.STRINGSLICE:
+		values := value.AsStringSlice()
+		changed := false
+		for i, s := range values {
+			if len(s) > maxLength {
+				values[i] = truncateUTF8(s, maxLength)
+				changed = true
+			}
+		}
+		if changed {
+			return attribute.StringSliceValue(values), true
+		}
+	}
+	return value, false
+}
+
+// budgetedSpan replaces the attributes of the wrapped span.
+type budgetedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs []attribute.KeyValue
+}
+
+func (s budgetedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
@@ -0,0 +1,255 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultAzureIngestionEndpoint = "https://dc.services.visualstudio.com"
+	azureTrackPath                = "/v2.1/track"
+	defaultAzureTimeout           = 10 * time.Second
+
+	azureRequestEnvelope    = "Microsoft.ApplicationInsights.Request"
+	azureDependencyEnvelope = "Microsoft.ApplicationInsights.RemoteDependency"
+)
+
+// azureConnectionString holds the parts of an Application Insights connection string we use.
+type azureConnectionString struct {
+	instrumentationKey string
+	ingestionEndpoint  string
+}
+
+// parseAzureConnectionString parses "InstrumentationKey=...;IngestionEndpoint=https://..."
+func parseAzureConnectionString(value string) (azureConnectionString, error) {
+	cs := azureConnectionString{ingestionEndpoint: defaultAzureIngestionEndpoint}
+	for _, part := range strings.Split(value, ";") {
+		key, val, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "instrumentationkey":
+			cs.instrumentationKey = val
+		case "ingestionendpoint":
+			cs.ingestionEndpoint = strings.TrimSuffix(val, "/")
+		}
+	}
+	if cs.instrumentationKey == "" {
+		return azureConnectionString{}, ErrInvalidAzureConnectionString
+	}
+	return cs, nil
+}
+
+// azureMonitorExporter converts spans to Application Insights request and dependency
+// telemetry and sends them to the ingestion endpoint of the connection string.
+type azureMonitorExporter struct {
+	instrumentationKey string
+	url                string
+	client             *http.Client
+}
+
+var _ sdktrace.SpanExporter = (*azureMonitorExporter)(nil)
+
+// newAzureMonitorExporter creates an exporter targeting Azure Monitor Application Insights
+func newAzureMonitorExporter(config TracerConfig) (sdktrace.SpanExporter, error) {
+	cs, err := parseAzureConnectionString(config.AzureConnectionString)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateAzureMonitorExporter, err)
+	}
+
+	return &azureMonitorExporter{
+		instrumentationKey: cs.instrumentationKey,
+		url:                cs.ingestionEndpoint + azureTrackPath,
+		client:             &http.Client{Timeout: defaultAzureTimeout},
+	}, nil
+}
+
+// ExportSpans sends the spans as a single batch of telemetry envelopes.
+func (e *azureMonitorExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	envelopes := make([]azureEnvelope, 0, len(spans))
+	for _, span := range spans {
+		envelopes = append(envelopes, newAzureEnvelope(e.instrumentationKey, span))
+	}
+
+	payload, err := json.Marshal(envelopes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMarshalSpans, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSendSpans, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSendSpans, err)
+	}
+	defer resp.Body.Close()
+
+	// 206 means part of the batch was rejected; those items are not retried.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("%w: status %d: %s", ErrSendSpans, resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Shutdown closes idle connections.
+func (e *azureMonitorExporter) Shutdown(_ context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+type azureEnvelope struct {
+	Name string            `json:"name"`
+	Time string            `json:"time"`
+	IKey string            `json:"iKey"`
+	Tags map[string]string `json:"tags"`
+	Data azureData         `json:"data"`
+}
+
+type azureData struct {
+	BaseType string        `json:"baseType"`
+	BaseData azureBaseData `json:"baseData"`
+}
+
+// azureBaseData covers both RequestData and RemoteDependencyData.
+type azureBaseData struct {
+	Ver          int               `json:"ver"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Duration     string            `json:"duration"`
+	Success      bool              `json:"success"`
+	ResponseCode string            `json:"responseCode,omitempty"`
+	URL          string            `json:"url,omitempty"`
+	ResultCode   string            `json:"resultCode,omitempty"`
+	Type         string            `json:"type,omitempty"`
+	Target       string            `json:"target,omitempty"`
+	Properties   map[string]string `json:"properties,omitempty"`
+}
+
+// newAzureEnvelope maps server and consumer spans to requests and all other spans to dependencies.
+func newAzureEnvelope(instrumentationKey string, span sdktrace.ReadOnlySpan) azureEnvelope {
+	attrs := attributeMap(span.Attributes())
+	statusCode := attrs[string(semconv.HTTPResponseStatusCodeKey)]
+
+	base := azureBaseData{
+		Ver:        2,
+		ID:         span.SpanContext().SpanID().String(),
+		Name:       span.Name(),
+		Duration:   formatAzureDuration(span.EndTime().Sub(span.StartTime())),
+		Success:    span.Status().Code != codes.Error,
+		Properties: attrs,
+	}
+
+	envelope := azureEnvelope{
+		Time: span.StartTime().UTC().Format(time.RFC3339Nano),
+		IKey: instrumentationKey,
+		Tags: azureTags(span),
+	}
+
+	switch span.SpanKind() {
+	case oteltrace.SpanKindServer, oteltrace.SpanKindConsumer:
+		base.ResponseCode = statusCode
+		if base.ResponseCode == "" {
+			base.ResponseCode = "0"
+		}
+		base.URL = attrs[string(semconv.URLFullKey)]
+		envelope.Name = azureRequestEnvelope
+		envelope.Data = azureData{BaseType: "RequestData", BaseData: base}
+	default:
+		base.ResultCode = statusCode
+		base.Type = azureDependencyType(span)
+		base.Target = attrs[string(semconv.ServerAddressKey)]
+		envelope.Name = azureDependencyEnvelope
+		envelope.Data = azureData{BaseType: "RemoteDependencyData", BaseData: base}
+	}
+
+	return envelope
+}
+
+// azureTags returns the context tags correlating the item with its operation and role.
+func azureTags(span sdktrace.ReadOnlySpan) map[string]string {
+	tags := map[string]string{
+		"ai.operation.id":   span.SpanContext().TraceID().String(),
+		"ai.operation.name": span.Name(),
+	}
+	if span.Parent().IsValid() {
+		tags["ai.operation.parentId"] = span.Parent().SpanID().String()
+	}
+	if res := span.Resource(); res != nil {
+		if name, ok := res.Set().Value(semconv.ServiceNameKey); ok {
+			tags["ai.cloud.role"] = name.Emit()
+		}
+		if instance, ok := res.Set().Value(semconv.ServiceInstanceIDKey); ok {
+			tags["ai.cloud.roleInstance"] = instance.Emit()
+		}
+	}
+	return tags
+}
+
+// azureDependencyType derives the dependency type shown in Application Insights.
+func azureDependencyType(span sdktrace.ReadOnlySpan) string {
+	for _, attr := range span.Attributes() {
+		switch attr.Key {
+		case semconv.HTTPRequestMethodKey:
+			return "HTTP"
+		case semconv.DBSystemNameKey:
+			return attr.Value.Emit()
+		case semconv.RPCSystemKey:
+			return attr.Value.Emit()
+		case semconv.MessagingSystemKey:
+			return attr.Value.Emit()
+		}
+	}
+	return "InProc"
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		m[string(attr.Key)] = attr.Value.Emit()
+	}
+	return m
+}
+
+// formatAzureDuration formats a duration as d.hh:mm:ss.fffffff
+func formatAzureDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+	ticks := d / 100 // 100ns ticks
+
+	return strconv.FormatInt(int64(days), 10) + "." +
+		fmt.Sprintf("%02d:%02d:%02d.%07d", hours, minutes, seconds, ticks)
+}
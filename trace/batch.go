@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	batchItemsKey        = attribute.Key("batch.items")
+	batchItemsTracedKey  = attribute.Key("batch.items.traced")
+	batchErrorsKey       = attribute.Key("batch.errors")
+	batchSampleEveryKey  = attribute.Key("batch.sample_every")
+	batchItemIndexKey    = attribute.Key("batch.item.index")
+	batchItemsSkippedKey = attribute.Key("batch.items.skipped")
+)
+
+// suppressKey marks a context in which Span returns no-op spans.
+type suppressKey struct{}
+
+func isSuppressed(ctx context.Context) bool {
+	suppressed, _ := ctx.Value(suppressKey{}).(bool)
+	return suppressed
+}
+
+// BatchSpan traces a batch job with a span for the batch and child spans for a sample of
+// its items. Create it with Batch. It is safe for concurrent use.
+type BatchSpan struct {
+	ctx         context.Context
+	span        oteltrace.Span
+	sampleEvery int64
+
+	items  atomic.Int64
+	traced atomic.Int64
+	errors atomic.Int64
+	ended  atomic.Bool
+}
+
+// Batch starts a span for a batch job. Only 1 in sampleEvery items processed with Item get
+// a span, all of them when sampleEvery is 1 or less, so jobs processing millions of rows
+// keep traces small. The batch span counts all items and errors. End it with End.
+func Batch(ctx context.Context, name string, sampleEvery int) (context.Context, *BatchSpan) {
+	sampleEvery = max(sampleEvery, 1)
+	ctx, span := Span(ctx, name, oteltrace.WithAttributes(batchSampleEveryKey.Int(sampleEvery)))
+	return ctx, &BatchSpan{ctx: ctx, span: span, sampleEvery: int64(sampleEvery)}
+}
+
+// Item runs fn for one item of the batch and returns its error. Sampled items run under a
+// child span named name, with the item index and the error recorded on it. Other items
+// run with a context in which Span creates no spans, so their nested operations are not
+// traced either. Errors of all items are counted on the batch span.
+func (b *BatchSpan) Item(name string, fn func(ctx context.Context) error) error {
+	index := b.items.Add(1) - 1
+
+	if index%b.sampleEvery != 0 {
+		err := fn(context.WithValue(b.ctx, suppressKey{}, true))
+		if err != nil {
+			b.errors.Add(1)
+		}
+		return err
+	}
+
+	b.traced.Add(1)
+	ctx, span := Span(b.ctx, name, oteltrace.WithAttributes(batchItemIndexKey.Int64(index)))
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		b.errors.Add(1)
+		RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// End sets the item counts on the batch span and ends it.
+func (b *BatchSpan) End() {
+	if b.ended.Swap(true) {
+		return
+	}
+
+	items, traced := b.items.Load(), b.traced.Load()
+	b.span.SetAttributes(
+		batchItemsKey.Int64(items),
+		batchItemsTracedKey.Int64(traced),
+		batchItemsSkippedKey.Int64(items-traced),
+		batchErrorsKey.Int64(b.errors.Load()),
+	)
+	b.span.End()
+}
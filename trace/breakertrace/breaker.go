@@ -0,0 +1,199 @@
+// Package breakertrace instruments sony/gobreaker circuit breakers: state transitions and
+// rejected calls are added as events to the span of the call and counted as metrics
+// through the metric package.
+package breakertrace
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"github.com/sony/gobreaker/v2"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	stateChangeEvent = "circuit_breaker.state_change"
+	rejectedEvent    = "circuit_breaker.rejected"
+
+	stateChangesName = "circuit_breaker.state_changes"
+	rejectedName     = "circuit_breaker.rejected"
+
+	nameKey  = attribute.Key("circuit_breaker.name")
+	stateKey = attribute.Key("circuit_breaker.state")
+	fromKey  = attribute.Key("circuit_breaker.state.from")
+	toKey    = attribute.Key("circuit_breaker.state.to")
+
+	// transitions kept to report the ones that happened during a call
+	maxTransitions = 8
+)
+
+// CircuitBreaker wraps a *gobreaker.CircuitBreaker. Create it with NewCircuitBreaker.
+type CircuitBreaker[T any] struct {
+	cb *gobreaker.CircuitBreaker[T]
+
+	mu          sync.Mutex
+	seq         uint64
+	transitions [maxTransitions]transition
+}
+
+type transition struct {
+	seq      uint64
+	from, to gobreaker.State
+}
+
+// NewCircuitBreaker creates a circuit breaker like gobreaker.NewCircuitBreaker. Every
+// state transition is counted as circuit_breaker.state_changes with the breaker name and
+// the from and to states, before st.OnStateChange is called.
+func NewCircuitBreaker[T any](st gobreaker.Settings) *CircuitBreaker[T] {
+	b := &CircuitBreaker[T]{}
+	onStateChange := st.OnStateChange
+	st.OnStateChange = func(name string, from, to gobreaker.State) {
+		b.transitioned(name, from, to)
+		if onStateChange != nil {
+			onStateChange(name, from, to)
+		}
+	}
+	b.cb = gobreaker.NewCircuitBreaker[T](st)
+	return b
+}
+
+// Unwrap returns the underlying circuit breaker.
+func (b *CircuitBreaker[T]) Unwrap() *gobreaker.CircuitBreaker[T] {
+	return b.cb
+}
+
+// Name returns the name of the circuit breaker.
+func (b *CircuitBreaker[T]) Name() string {
+	return b.cb.Name()
+}
+
+// State returns the current state of the circuit breaker.
+func (b *CircuitBreaker[T]) State() gobreaker.State {
+	return b.cb.State()
+}
+
+// Counts returns the internal counters of the circuit breaker.
+func (b *CircuitBreaker[T]) Counts() gobreaker.Counts {
+	return b.cb.Counts()
+}
+
+// Execute runs req like gobreaker.CircuitBreaker.Execute. The state transitions that
+// happened while it ran, from any caller, are added as circuit_breaker.state_change events
+// to the span in ctx. A call rejected by an open or half-open breaker adds a
+// circuit_breaker.rejected event with the state and is counted as circuit_breaker.rejected.
+func (b *CircuitBreaker[T]) Execute(ctx context.Context, req func() (T, error)) (T, error) {
+	span := oteltrace.SpanFromContext(ctx)
+	before := b.lastSeq()
+
+	value, err := b.cb.Execute(req)
+
+	name := nameKey.String(b.cb.Name())
+	for _, t := range b.transitionsSince(before) {
+		span.AddEvent(stateChangeEvent, oteltrace.WithAttributes(
+			name,
+			fromKey.String(t.from.String()),
+			toKey.String(t.to.String()),
+		))
+	}
+
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		state := stateKey.String(stateName(err))
+		span.AddEvent(rejectedEvent, oteltrace.WithAttributes(name, state))
+		globalInstruments.get().rejected.Add(ctx, 1, otelmetric.WithAttributes(name, state))
+	}
+	return value, err
+}
+
+// transitioned records a transition. gobreaker calls it with its lock held.
+func (b *CircuitBreaker[T]) transitioned(name string, from, to gobreaker.State) {
+	b.mu.Lock()
+	b.seq++
+	b.transitions[b.seq%maxTransitions] = transition{seq: b.seq, from: from, to: to}
+	b.mu.Unlock()
+
+	globalInstruments.get().stateChanges.Add(context.Background(), 1, otelmetric.WithAttributes(
+		nameKey.String(name),
+		fromKey.String(from.String()),
+		toKey.String(to.String()),
+	))
+}
+
+func (b *CircuitBreaker[T]) lastSeq() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.seq
+}
+
+// transitionsSince returns the kept transitions after seq, oldest first.
+func (b *CircuitBreaker[T]) transitionsSince(seq uint64) []transition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var transitions []transition
+	for s := max(seq+1, b.seq-min(b.seq, maxTransitions-1)); s <= b.seq; s++ {
+		transitions = append(transitions, b.transitions[s%maxTransitions])
+	}
+	return transitions
+}
+
+// stateName returns the state that rejected a call with err.
+func stateName(err error) string {
+	if errors.Is(err, gobreaker.ErrOpenState) {
+		return gobreaker.StateOpen.String()
+	}
+	return gobreaker.StateHalfOpen.String()
+}
+
+var globalInstruments = &instruments{}
+
+// counters are the instruments of one meter.
+type counters struct {
+	stateChanges otelmetric.Int64Counter
+	rejected     otelmetric.Int64Counter
+}
+
+// instruments holds the counters of the current global meter.
+type instruments struct {
+	mu      sync.Mutex
+	meter   otelmetric.Meter
+	current *counters
+}
+
+// get returns the counters of the current global meter, recreating them when the metric
+// package was (re)initialized. Counters that fail to be created are no-ops.
+func (i *instruments) get() *counters {
+	meter := metric.Meter()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if meter == i.meter && i.current != nil {
+		return i.current
+	}
+
+	current := &counters{}
+	var err error
+	if current.stateChanges, err = meter.Int64Counter(
+		stateChangesName,
+		otelmetric.WithDescription("Number of circuit breaker state transitions"),
+		otelmetric.WithUnit("{transition}"),
+	); err != nil {
+		current.stateChanges = noop.Int64Counter{}
+	}
+	if current.rejected, err = meter.Int64Counter(
+		rejectedName,
+		otelmetric.WithDescription("Number of calls rejected by an open or half-open circuit breaker"),
+		otelmetric.WithUnit("{call}"),
+	); err != nil {
+		current.rejected = noop.Int64Counter{}
+	}
+
+	i.meter = meter
+	i.current = current
+	return current
+}
@@ -0,0 +1,103 @@
+// Package buntrace traces uptrace/bun queries through the global tracer configured by the
+// trace package, with a query hook creating a client span per query.
+package buntrace
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSpanName = "bun"
+
+	rowsAffectedKey = attribute.Key("db.rows_affected")
+)
+
+// dialectSystems maps bun dialects to db.system.name values.
+var dialectSystems = map[dialect.Name]string{
+	dialect.PG:     "postgresql",
+	dialect.MySQL:  "mysql",
+	dialect.SQLite: "sqlite",
+	dialect.MSSQL:  "microsoft.sql_server",
+	dialect.Oracle: "oracle.db",
+}
+
+// QueryHook is a bun.QueryHook creating a client span per query, named after the
+// operation and the table, e.g. "SELECT users".
+type QueryHook struct{}
+
+var _ bun.QueryHook = (*QueryHook)(nil)
+
+// NewQueryHook returns a query hook to register with db.AddQueryHook.
+func NewQueryHook() *QueryHook {
+	return &QueryHook{}
+}
+
+// BeforeQuery starts the span of the query.
+func (h *QueryHook) BeforeQuery(ctx context.Context, e *bun.QueryEvent) context.Context {
+	operation := strings.ToUpper(e.Operation())
+	table := tableName(e)
+
+	ctx, span := trace.Span(ctx, spanName(operation, table),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+	)
+	trace.SetDBAttributes(span, trace.DBInfo{
+		System:    dialectSystem(e.DB),
+		Operation: operation,
+		Statement: e.Query,
+		Table:     table,
+	})
+	return ctx
+}
+
+// AfterQuery records the rows affected by the query, or its error, and ends the span.
+// sql.ErrNoRows is not recorded as an error.
+func (h *QueryHook) AfterQuery(ctx context.Context, e *bun.QueryEvent) {
+	span := oteltrace.SpanFromContext(ctx)
+	defer span.End()
+
+	if e.Result != nil {
+		if rows, err := e.Result.RowsAffected(); err == nil {
+			span.SetAttributes(rowsAffectedKey.Int64(rows))
+		}
+	}
+	if e.Err != nil && !errors.Is(e.Err, sql.ErrNoRows) {
+		trace.RecordError(ctx, e.Err)
+	}
+}
+
+// tableName returns the unquoted table of the query, or "" for raw queries.
+func tableName(e *bun.QueryEvent) string {
+	if e.IQuery == nil {
+		return ""
+	}
+	return strings.Trim(e.IQuery.GetTableName(), "\"`[]")
+}
+
+// spanName builds a low-cardinality span name such as "SELECT users".
+func spanName(operation, table string) string {
+	name := strings.TrimSpace(operation + " " + table)
+	if name == "" {
+		return defaultSpanName
+	}
+	return name
+}
+
+func dialectSystem(db *bun.DB) string {
+	if db == nil {
+		return ""
+	}
+	name := db.Dialect().Name()
+	if system, ok := dialectSystems[name]; ok {
+		return system
+	}
+	return name.String()
+}
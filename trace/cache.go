@@ -0,0 +1,180 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	cacheHitEvent  = "cache.hit"
+	cacheMissEvent = "cache.miss"
+	cacheSetEvent  = "cache.set"
+
+	cacheRequestsName = "cache.requests"
+
+	cacheResultHit   = "hit"
+	cacheResultMiss  = "miss"
+	cacheResultError = "error"
+
+	cacheNameKey    = attribute.Key("cache.name")
+	cacheHitKey     = attribute.Key("cache.hit")
+	cacheKeyHashKey = attribute.Key("cache.key.hash")
+	cacheTTLKey     = attribute.Key("cache.ttl_ms")
+	cacheResultKey  = attribute.Key("cache.result")
+)
+
+// Cache is the interface of the caches WrapCache decorates. Get reports whether key was
+// found. A ttl of 0 means the default expiration of the cache.
+type Cache[K comparable, V any] interface {
+	Get(ctx context.Context, key K) (V, bool, error)
+	Set(ctx context.Context, key K, value V, ttl time.Duration) error
+}
+
+// CacheConfig configures WrapCache.
+type CacheConfig struct {
+	// Name is recorded as cache.name, e.g. "sessions"
+	Name string
+	// Spans creates a span per Get and Set instead of adding events to the span in ctx
+	Spans bool
+}
+
+// WrapCache decorates cache so that Get adds a cache.hit or cache.miss event and Set a
+// cache.set event with the TTL to the span in ctx, or creates spans with config.Spans.
+// Keys are recorded as a hash, cache.key.hash, not in clear. Through the metric package,
+// gets are counted as cache.requests by cache.name and cache.result (hit, miss or error),
+// from which hit ratios are derived.
+func WrapCache[K comparable, V any](cache Cache[K, V], config CacheConfig) Cache[K, V] {
+	return &tracedCache[K, V]{cache: cache, config: config, name: cacheNameKey.String(config.Name)}
+}
+
+type tracedCache[K comparable, V any] struct {
+	cache  Cache[K, V]
+	config CacheConfig
+	name   attribute.KeyValue
+}
+
+func (c *tracedCache[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+	ctx, span, end := c.start(ctx, "get")
+	defer end()
+
+	value, found, err := c.cache.Get(ctx, key)
+
+	result := cacheResultMiss
+	switch {
+	case err != nil:
+		result = cacheResultError
+		c.recordError(ctx, span, err)
+	case found:
+		result = cacheResultHit
+	}
+	cacheInstruments.get().requests.Add(ctx, 1, otelmetric.WithAttributes(c.name, cacheResultKey.String(result)))
+
+	if err == nil {
+		attrs := []attribute.KeyValue{c.name, cacheKeyHash(key)}
+		if c.config.Spans {
+			span.SetAttributes(append(attrs, cacheHitKey.Bool(found))...)
+		} else if found {
+			span.AddEvent(cacheHitEvent, oteltrace.WithAttributes(attrs...))
+		} else {
+			span.AddEvent(cacheMissEvent, oteltrace.WithAttributes(attrs...))
+		}
+	}
+	return value, found, err
+}
+
+func (c *tracedCache[K, V]) Set(ctx context.Context, key K, value V, ttl time.Duration) error {
+	ctx, span, end := c.start(ctx, "set")
+	defer end()
+
+	attrs := []attribute.KeyValue{c.name, cacheKeyHash(key), cacheTTLKey.Int64(ttl.Milliseconds())}
+	if c.config.Spans {
+		span.SetAttributes(attrs...)
+	}
+
+	if err := c.cache.Set(ctx, key, value, ttl); err != nil {
+		c.recordError(ctx, span, err)
+		return err
+	}
+	if !c.config.Spans {
+		span.AddEvent(cacheSetEvent, oteltrace.WithAttributes(attrs...))
+	}
+	return nil
+}
+
+// start returns the span to record the operation on: a new span with config.Spans, the
+// span in ctx otherwise.
+func (c *tracedCache[K, V]) start(ctx context.Context, operation string) (context.Context, oteltrace.Span, func()) {
+	if !c.config.Spans {
+		return ctx, oteltrace.SpanFromContext(ctx), func() {}
+	}
+	ctx, span := Span(ctx, "cache "+operation+" "+c.config.Name)
+	return ctx, span, func() { span.End() }
+}
+
+// recordError records err on the operation's span, or as an event on the span in ctx,
+// leaving its status to the caller, who may fall back to the source.
+func (c *tracedCache[K, V]) recordError(ctx context.Context, span oteltrace.Span, err error) {
+	if c.config.Spans {
+		RecordError(ctx, err)
+		return
+	}
+	span.RecordError(err, oteltrace.WithAttributes(c.name))
+}
+
+// cacheKeyHash hashes the key so that keys holding personal data are not exported.
+func cacheKeyHash[K comparable](key K) attribute.KeyValue {
+	h := fnv.New64a()
+	_, _ = fmt.Fprint(h, key)
+	return cacheKeyHashKey.String(strconv.FormatUint(h.Sum64(), 16))
+}
+
+var cacheInstruments = &cacheCounters{}
+
+// cacheCounterSet are the cache instruments of one meter.
+type cacheCounterSet struct {
+	requests otelmetric.Int64Counter
+}
+
+// cacheCounters holds the cache instruments of the current global meter.
+type cacheCounters struct {
+	mu      sync.Mutex
+	meter   otelmetric.Meter
+	current *cacheCounterSet
+}
+
+// get returns the instruments of the current global meter, recreating them when the
+// metric package was (re)initialized. Instruments that fail to be created are no-ops.
+func (c *cacheCounters) get() *cacheCounterSet {
+	meter := metric.Meter()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if meter == c.meter && c.current != nil {
+		return c.current
+	}
+
+	current := &cacheCounterSet{}
+	var err error
+	if current.requests, err = meter.Int64Counter(
+		cacheRequestsName,
+		otelmetric.WithDescription("Number of cache gets by result"),
+		otelmetric.WithUnit("{request}"),
+	); err != nil {
+		current.requests = noop.Int64Counter{}
+	}
+
+	c.meter = meter
+	c.current = current
+	return current
+}
@@ -0,0 +1,72 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Inject writes the trace context and baggage of ctx into carrier using the configured
+// propagator. It does nothing when carrier is nil, as a nil map cannot be written.
+func Inject(ctx context.Context, carrier map[string]string) {
+	if carrier == nil {
+		return
+	}
+	InjectCarrier(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns a copy of ctx with the trace context and baggage read from carrier.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return ExtractCarrier(ctx, propagation.MapCarrier(carrier))
+}
+
+// InjectCarrier is like Inject for any propagation.TextMapCarrier, e.g. the carriers below
+// or kafkatrace.HeadersCarrier.
+func InjectCarrier(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// ExtractCarrier is like Extract for any propagation.TextMapCarrier.
+func ExtractCarrier(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// HTTPHeaderCarrier adapts http.Header to propagation.TextMapCarrier.
+type HTTPHeaderCarrier = propagation.HeaderCarrier
+
+// AMQPTableCarrier adapts AMQP message headers to propagation.TextMapCarrier.
+// amqp091-go tables convert directly: AMQPTableCarrier(delivery.Headers).
+// The table must be non-nil to inject into it.
+type AMQPTableCarrier map[string]any
+
+var _ propagation.TextMapCarrier = AMQPTableCarrier{}
+
+// Get returns the value for the given key, converting byte slices and other types to strings.
+func (c AMQPTableCarrier) Get(key string) string {
+	switch value := c[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Set stores the value as a string.
+func (c AMQPTableCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys lists the table keys.
+func (c AMQPTableCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,150 @@
+// Package clitrace wraps cobra command execution in spans through the global tracer
+// configured by the trace package, so CLI tools produce one trace per invocation.
+package clitrace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	redactedValue       = "REDACTED"
+	defaultFlushTimeout = 5 * time.Second
+
+	commandPathKey = attribute.Key("cli.command.path")
+	argsKey        = attribute.Key("cli.args")
+	flagKeyPrefix  = "cli.flag."
+)
+
+// DefaultRedactedFlags are matched against flag and key=value argument names when the
+// configuration does not list any. A name containing one of them is redacted.
+var DefaultRedactedFlags = []string{"password", "passwd", "secret", "token", "key", "credential"}
+
+// Config configures the command instrumentation.
+type Config struct {
+	// RedactFlags lists name fragments whose flag values are recorded as REDACTED,
+	// DefaultRedactedFlags when nil.
+	RedactFlags []string
+	// FlushTimeout bounds the flush after the command returns, default 5s.
+	FlushTimeout time.Duration
+}
+
+// Execute instruments root and its subcommands and executes it with ctx.
+func Execute(ctx context.Context, root *cobra.Command, config Config) error {
+	Instrument(root, config)
+	return root.ExecuteContext(ctx)
+}
+
+// Instrument wraps Run and RunE of root and all its subcommands. The wrapped command
+// runs inside a span named after the command path (e.g. "tool users create") that records
+// the arguments and the flags set on the command line, and the spans are flushed when
+// the command returns so they are exported before the process exits.
+func Instrument(root *cobra.Command, config Config) {
+	if config.RedactFlags == nil {
+		config.RedactFlags = DefaultRedactedFlags
+	}
+	if config.FlushTimeout == 0 {
+		config.FlushTimeout = defaultFlushTimeout
+	}
+
+	instrument(root, config)
+}
+
+func instrument(cmd *cobra.Command, config Config) {
+	for _, child := range cmd.Commands() {
+		instrument(child, config)
+	}
+
+	run := cmd.RunE
+	if run == nil && cmd.Run != nil {
+		plain := cmd.Run
+		run = func(cmd *cobra.Command, args []string) error {
+			plain(cmd, args)
+			return nil
+		}
+	}
+	if run == nil {
+		return
+	}
+
+	cmd.Run = nil
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runInSpan(cmd, args, run, config)
+	}
+}
+
+func runInSpan(cmd *cobra.Command, args []string, run func(*cobra.Command, []string) error, config Config) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, span := trace.Span(ctx, cmd.CommandPath())
+	span.SetAttributes(commandAttributes(cmd, args, config.RedactFlags)...)
+	cmd.SetContext(ctx)
+
+	err := run(cmd, args)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.FlushTimeout)
+	defer cancel()
+	if flushErr := trace.ForceFlush(flushCtx); flushErr != nil && !errors.Is(flushErr, trace.ErrNotInitialized) {
+		cmd.PrintErrln("failed to flush traces:", flushErr)
+	}
+
+	return err
+}
+
+// commandAttributes returns the command path, arguments and changed flags.
+func commandAttributes(cmd *cobra.Command, args []string, redact []string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{commandPathKey.String(cmd.CommandPath())}
+
+	if len(args) > 0 {
+		recorded := make([]string, len(args))
+		for i, arg := range args {
+			recorded[i] = redactArg(arg, redact)
+		}
+		attrs = append(attrs, argsKey.StringSlice(recorded))
+	}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if isSensitive(flag.Name, redact) {
+			value = redactedValue
+		}
+		attrs = append(attrs, attribute.String(flagKeyPrefix+flag.Name, value))
+	})
+
+	return attrs
+}
+
+// redactArg redacts the value of name=value arguments with a sensitive name.
+func redactArg(arg string, redact []string) string {
+	name, _, found := strings.Cut(arg, "=")
+	if found && isSensitive(name, redact) {
+		return name + "=" + redactedValue
+	}
+	return arg
+}
+
+func isSensitive(name string, redact []string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range redact {
+		if strings.Contains(name, strings.ToLower(fragment)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package trace
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// packagePrefix identifies frames of this package, which are skipped when looking for
+// the code that created a span. Subpackages such as httptrace are not skipped.
+const packagePrefix = "github.com/cristiano-pacheco/go-otel/trace."
+
+const defaultAutoSpanName = "unknown"
+
+// CodeAttributes returns code.function.name, code.file.path and code.line.number of the
+// function calling it, to tag a single span with the source that created it:
+//
+//	ctx, span := trace.Span(ctx, "load", oteltrace.WithAttributes(trace.CodeAttributes()...))
+//
+// TracerConfig.CodeAttributes does the same for every span created with Span.
+func CodeAttributes() []attribute.KeyValue {
+	frame, ok := callerFrame()
+	if !ok {
+		return nil
+	}
+	return []attribute.KeyValue{
+		semconv.CodeFunctionName(frame.Function),
+		semconv.CodeFilePath(frame.File),
+		semconv.CodeLineNumber(frame.Line),
+	}
+}
+
+// callerFrame returns the first frame outside of this package.
+func callerFrame() (runtime.Frame, bool) {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			return frame, frame.Function != ""
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// SpanAuto is like Span but names the span after the calling function, e.g.
+// "orders.(*Service).Create" becomes "orders.Service.Create", so span names cannot drift
+// from function names. Closures keep the suffix of their enclosing function (".func1").
+func SpanAuto(ctx context.Context, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	name := defaultAutoSpanName
+	if frame, ok := callerFrame(); ok {
+		name = functionSpanName(frame.Function)
+	}
+	return Span(ctx, name, opts...)
+}
+
+// functionSpanName strips the import path and the pointer receiver notation from a
+// fully qualified function name.
+func functionSpanName(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(function)
+}
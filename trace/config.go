@@ -1,12 +1,18 @@
 package trace
 
 import (
+	"fmt"
 	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 const (
-	defaultBatchTimeout = 5 * time.Second
-	defaultSampleRate   = 0.01
+	defaultBatchTimeout    = 5 * time.Second
+	defaultShutdownTimeout = 10 * time.Second
+	defaultSampleRate      = 0.01
+	noopAppName            = "noop"
 )
 
 type TracerConfig struct {
@@ -19,7 +25,106 @@ type TracerConfig struct {
 	MaxBatchSize int
 	Insecure     bool
 	SampleRate   float64      // 0.0 to 1.0
-	ExporterType ExporterType // GRPC or HTTP, default GRPC
+	ExporterType ExporterType // GRPC, HTTP or Kafka, default GRPC
+	KafkaBrokers []string     // Kafka broker addresses, required for the Kafka exporter
+	KafkaTopic   string       // Kafka topic spans are published to, required for the Kafka exporter
+	HTTPJSON     bool         // Use OTLP JSON encoding instead of protobuf, HTTP exporter only
+	// SpanNameRules rewrite span names at start to limit cardinality, see DefaultSpanNameRules
+	SpanNameRules []SpanNameRule
+	// SpanWatchdogTimeout logs a warning with the creation stack for spans created via Span
+	// that are still open after this duration. Intended for debugging, 0 disables it.
+	SpanWatchdogTimeout time.Duration
+	// TenantSampleRates overrides SampleRate per tenant, keyed by the value of the
+	// TenantBaggageKey baggage member (default "tenant.id"). Rates are 0.0 to 1.0.
+	TenantSampleRates map[string]float64
+	TenantBaggageKey  string
+	// KindSampleRates overrides SampleRate per kind of local root span, e.g. 1.0 for
+	// oteltrace.SpanKindConsumer and 0.01 for oteltrace.SpanKindInternal; child spans follow
+	// their parent. Per-tenant rates take precedence. Rates are 0.0 to 1.0.
+	KindSampleRates map[oteltrace.SpanKind]float64
+	// SampleRateSchedule applies its rates in order from Initialize, each for the step's
+	// Duration, before settling on SampleRate, e.g. to fully trace a new release for a while
+	SampleRateSchedule []SampleRateStep
+	// ConsistentSampling applies the sample rates with consistent probability sampling,
+	// recording the sampling probability as the p-value of the "ot" tracestate member so
+	// that downstream services and backends can compute the adjusted count of every span
+	ConsistentSampling bool
+	// DebugHeader names a request header (e.g. "X-Debug-Trace") that forces sampling of the
+	// request's trace when set to "1" or "true". Honored by httptrace and grpctrace.
+	DebugHeader string
+	// SemconvStability selects stable, legacy or both HTTP attribute sets for the built-in
+	// instrumentation. Defaults to OTEL_SEMCONV_STABILITY_OPT_IN, then to stable.
+	SemconvStability SemconvStability
+	// ServiceInstanceID overrides service.instance.id, by default a UUID generated once per process
+	ServiceInstanceID string
+	// OpenTracingBridge installs the OpenTracing bridge as the opentracing-go global tracer,
+	// so code instrumented with opentracing-go produces spans through this provider.
+	OpenTracingBridge bool
+	// ValidateEndpoint makes Initialize check that the OTLP collector is reachable, with a
+	// DNS lookup, a TCP connection, a TLS handshake unless Insecure and, for the HTTP
+	// exporter, an empty export request, and fail with ErrEndpointDNS, ErrEndpointRefused,
+	// ErrEndpointUnreachable, ErrEndpointTLS, ErrEndpointNotFound or ErrEndpointRejected.
+	ValidateEndpoint bool
+	// LazyExporter creates the exporter in the background instead of failing Initialize
+	// when it cannot be created, e.g. without Google Cloud credentials yet, or when the
+	// OTLP collector is unreachable, checked as with ValidateEndpoint. Creation is retried
+	// with exponential backoff up to a minute and spans are dropped meanwhile.
+	// By default Initialize fails fast with ErrCreateExporter.
+	LazyExporter bool
+	// Headers are sent with every export request (e.g. API keys), gRPC and HTTP exporters
+	Headers map[string]string
+	// GlobalAttributes are set on every span started, e.g. deployment.environment.name.
+	// Unlike the resource, they can be changed with Reload.
+	GlobalAttributes map[string]string
+	// URLPath overrides the OTLP/HTTP traces path, default "/v1/traces"
+	URLPath string
+	// GCPProjectID is the Google Cloud project for the googlecloud exporter,
+	// default is the project of the Application Default Credentials
+	GCPProjectID string
+	// AzureConnectionString is the Application Insights connection string for the azuremonitor exporter
+	AzureConnectionString string
+	// ErrorBiasedSampling records every span and additionally exports traces whose local
+	// root span ends with an error status, even when SampleRate would drop them
+	ErrorBiasedSampling bool
+	// LatencyThreshold records every span and additionally exports traces whose local root
+	// span lasts longer than this duration, even when SampleRate would drop them. 0 disables it.
+	LatencyThreshold time.Duration
+	// SpanMetrics derives call count and duration metrics per span name, kind and status from
+	// every ended span and records them through the metric package. It records all spans,
+	// unsampled ones included, so the metrics are not affected by SampleRate.
+	SpanMetrics bool
+	// StatementObfuscation selects how database integrations sanitize db.query.text,
+	// default literals. StatementMaxLength caps its length in bytes, default 2048.
+	StatementObfuscation StatementObfuscation
+	StatementMaxLength   int
+	// PipelineMetrics reports the trace.spans.in_flight and trace.export.queue.utilization
+	// gauges through the metric package, to alert before spans are dropped. The metric
+	// package must be initialized before the tracer.
+	PipelineMetrics bool
+	// MaxQueueSize is the number of ended spans the batch processor buffers before dropping, default 2048
+	MaxQueueSize int
+	// SyncExport exports every span when it ends instead of batching. Slower, but nothing
+	// is buffered, which suits short-lived processes. Batch settings are ignored.
+	SyncExport bool
+	// Profile presets BatchTimeout, MaxBatchSize, MaxQueueSize and SyncExport for a workload.
+	// Fields set explicitly take precedence.
+	Profile Profile
+	// SlowSpanThresholds sets slow=true on exported spans lasting longer than the threshold
+	// for their name. The SlowSpanDefaultName ("*") entry applies to all other spans.
+	SlowSpanThresholds map[string]time.Duration
+	// AttributeBudget caps the attribute count and string value length of exported spans,
+	// below the limits of the backend, keeping its Keep keys first and recording what was
+	// dropped or truncated on the span
+	AttributeBudget AttributeBudget
+	// RecordErrorChain makes RecordError add an exception.cause event for every error
+	// wrapped by the recorded one (errors.Unwrap and errors.Join)
+	RecordErrorChain bool
+	// CodeAttributes tags every span created with Span with the code.function.name,
+	// code.file.path and code.line.number of its caller, see also CodeAttributes()
+	CodeAttributes bool
+	// ShutdownTimeout bounds Shutdown when its context has no deadline, so an unreachable
+	// collector cannot hang process exit. Default 10s.
+	ShutdownTimeout time.Duration
 }
 
 // Validate checks if the configuration is valid
@@ -27,26 +132,91 @@ func (c *TracerConfig) Validate() error {
 	if c.AppName == "" {
 		return ErrAppNameRequired
 	}
-	if c.TraceEnabled && c.TraceURL == "" {
-		return ErrTraceURLRequired
+	if c.TraceEnabled {
+		if err := c.validateExporter(); err != nil {
+			return err
+		}
 	}
 	if c.SampleRate < 0.0 || c.SampleRate > 1.0 {
 		return ErrInvalidSampleRate
 	}
+	for tenant, rate := range c.TenantSampleRates {
+		if rate < 0.0 || rate > 1.0 {
+			return fmt.Errorf("%w: tenant %q", ErrInvalidSampleRate, tenant)
+		}
+	}
+	for kind, rate := range c.KindSampleRates {
+		if rate < 0.0 || rate > 1.0 {
+			return fmt.Errorf("%w: kind %s", ErrInvalidSampleRate, kind)
+		}
+	}
+	if err := validateSampleRateSchedule(c.SampleRateSchedule); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateExporter checks the settings required by the selected exporter
+func (c *TracerConfig) validateExporter() error {
+	if c.ExporterType.IsKafka() {
+		if len(c.KafkaBrokers) == 0 {
+			return ErrKafkaBrokersRequired
+		}
+		if c.KafkaTopic == "" {
+			return ErrKafkaTopicRequired
+		}
+		return nil
+	}
+	if c.ExporterType.IsAzureMonitor() {
+		if c.AzureConnectionString == "" {
+			return ErrAzureConnectionStringRequired
+		}
+		_, err := parseAzureConnectionString(c.AzureConnectionString)
+		return err
+	}
+	if c.ExporterType.IsGoogleCloud() {
+		// TraceURL is optional, the exporter defaults to the Cloud Telemetry API endpoint
+		return nil
+	}
+	if c.ExporterType.IsStdout() {
+		return nil
+	}
+	if c.TraceURL == "" {
+		return ErrTraceURLRequired
+	}
 	return nil
 }
 
 // setDefaults sets default values for optional configuration fields
 func (c *TracerConfig) setDefaults() {
+	c.Profile.apply(c)
 	if c.BatchTimeout == 0 {
 		c.BatchTimeout = defaultBatchTimeout
 	}
 	if c.MaxBatchSize == 0 {
 		c.MaxBatchSize = 512
 	}
+	if c.ShutdownTimeout == 0 {
+		c.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if c.MaxQueueSize == 0 {
+		c.MaxQueueSize = sdktrace.DefaultMaxQueueSize
+	}
 	if c.SampleRate == 0.0 {
 		c.SampleRate = defaultSampleRate
 	}
+	if len(c.TenantSampleRates) > 0 && c.TenantBaggageKey == "" {
+		c.TenantBaggageKey = defaultTenantBaggageKey
+	}
+	if c.StatementObfuscation.IsZero() {
+		c.StatementObfuscation = defaultStatementSanitizer.obfuscation
+	}
+	if c.StatementMaxLength == 0 {
+		c.StatementMaxLength = defaultStatementMaxLength
+	}
+	if c.SemconvStability.IsZero() {
+		c.SemconvStability = semconvStabilityFromEnv()
+	}
 	if c.ExporterType.IsZero() {
 		exporterType, err := NewExporterType(ExporterTypeGRPC)
 		if err == nil {
@@ -0,0 +1,165 @@
+package trace
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// otTraceStateKey is the OpenTelemetry member of the W3C tracestate
+	otTraceStateKey = "ot"
+
+	// pValueNever is the p-value of a zero sampling probability
+	pValueNever = 63
+	// rValueMax is the largest r-value, the number of leading zeros of 62 random bits
+	rValueMax = 62
+)
+
+// newRatioSampler returns the sampler applying a sample rate, consistent probability
+// sampling when enabled and sdktrace.TraceIDRatioBased otherwise.
+func newRatioSampler(consistent bool) func(rate float64) sdktrace.Sampler {
+	if consistent {
+		return newConsistentSampler
+	}
+	return sdktrace.TraceIDRatioBased
+}
+
+// consistentSampler implements consistent probability sampling with the p-value and
+// r-value of the "ot" tracestate member. A root span is sampled when the r-value of its
+// trace, shared by every service, is at least the p-value of the sampling probability 2^-p,
+// so services sampling at the same rate keep the same traces and backends can compute the
+// adjusted count 2^p of every sampled span. Probabilities that are not powers of two are
+// interpolated between the two nearest p-values, chosen from the trace ID so that every
+// service picks the same one. Child spans follow their parent, keeping its tracestate.
+type consistentSampler struct {
+	probability float64
+	lowerP      int     // p-value of the nearest power of two at or above probability
+	lowerProb   float64 // chance of choosing lowerP over lowerP+1
+}
+
+func newConsistentSampler(probability float64) sdktrace.Sampler {
+	return sdktrace.ParentBased(newConsistentRootSampler(probability))
+}
+
+func newConsistentRootSampler(probability float64) *consistentSampler {
+	s := &consistentSampler{probability: probability}
+	switch {
+	case probability >= 1:
+		s.lowerP, s.lowerProb = 0, 1
+	case probability <= 0:
+		s.lowerP, s.lowerProb = pValueNever, 1
+	default:
+		s.lowerP = min(int(math.Floor(-math.Log2(probability))), rValueMax)
+		upper := math.Ldexp(1, -(s.lowerP + 1))
+		if s.lowerP == rValueMax {
+			upper = 0
+		}
+		// probability = lowerProb * 2^-lowerP + (1 - lowerProb) * 2^-(lowerP+1)
+		s.lowerProb = (probability - upper) / (math.Ldexp(1, -s.lowerP) - upper)
+	}
+	return s
+}
+
+func (s *consistentSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := oteltrace.SpanContextFromContext(p.ParentContext).TraceState()
+	ot := parseOTTraceState(parent.Get(otTraceStateKey))
+
+	r, ok := ot.rValue()
+	if !ok {
+		r = rValueFromTraceID(p.TraceID)
+	}
+
+	pValue := s.lowerP
+	if s.lowerProb < 1 && pValue < pValueNever && interpolationValue(p.TraceID) >= s.lowerProb {
+		pValue++
+	}
+
+	decision := sdktrace.Drop
+	ot.set("r", strconv.Itoa(r))
+	ot.remove("p")
+	if pValue < pValueNever && r >= pValue {
+		decision = sdktrace.RecordAndSample
+		ot.set("p", strconv.Itoa(pValue))
+	}
+
+	tracestate, err := parent.Insert(otTraceStateKey, ot.String())
+	if err != nil {
+		tracestate = parent
+	}
+	return sdktrace.SamplingResult{Decision: decision, Tracestate: tracestate}
+}
+
+func (s *consistentSampler) Description() string {
+	return fmt.Sprintf("ConsistentProbabilityBased{%g}", s.probability)
+}
+
+// rValueFromTraceID derives the r-value from the random part of the trace ID, so that
+// services receiving a trace without an r-value agree on it.
+func rValueFromTraceID(traceID oteltrace.TraceID) int {
+	random := binary.BigEndian.Uint64(traceID[8:]) & (1<<rValueMax - 1)
+	return min(bits.LeadingZeros64(random)-(64-rValueMax), rValueMax)
+}
+
+// interpolationValue derives a value in [0, 1) from the first half of the trace ID, which
+// the r-value does not use, to choose between the two p-values of a probability.
+func interpolationValue(traceID oteltrace.TraceID) float64 {
+	return float64(binary.BigEndian.Uint64(traceID[:8])>>11) / (1 << 53)
+}
+
+// otTraceState is the value of the "ot" tracestate member, e.g. "p:2;r:5", with its
+// fields in order so that unknown ones are propagated unchanged.
+type otTraceState [][2]string
+
+func parseOTTraceState(value string) otTraceState {
+	var ot otTraceState
+	for _, field := range strings.Split(value, ";") {
+		if key, val, ok := strings.Cut(field, ":"); ok && key != "" {
+			ot = append(ot, [2]string{key, val})
+		}
+	}
+	return ot
+}
+
+func (ot otTraceState) rValue() (int, bool) {
+	for _, field := range ot {
+		if field[0] == "r" {
+			r, err := strconv.Atoi(field[1])
+			return r, err == nil && r >= 0 && r <= rValueMax
+		}
+	}
+	return 0, false
+}
+
+func (ot *otTraceState) set(key, value string) {
+	for i, field := range *ot {
+		if field[0] == key {
+			(*ot)[i][1] = value
+			return
+		}
+	}
+	*ot = append(*ot, [2]string{key, value})
+}
+
+func (ot *otTraceState) remove(key string) {
+	for i, field := range *ot {
+		if field[0] == key {
+			*ot = append((*ot)[:i], (*ot)[i+1:]...)
+			return
+		}
+	}
+}
+
+func (ot otTraceState) String() string {
+	fields := make([]string, len(ot))
+	for i, field := range ot {
+		fields[i] = field[0] + ":" + field[1]
+	}
+	return strings.Join(fields, ";")
+}
@@ -0,0 +1,63 @@
+package trace
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// EnqueueTimeKey is the carrier key holding the time a message was enqueued, in Unix
+// milliseconds. Producers set it with SetEnqueueTime.
+const EnqueueTimeKey = "enqueued_at"
+
+const messagingLatencyKey = attribute.Key("messaging.latency_ms")
+
+// SetEnqueueTime stores t in the carrier under EnqueueTimeKey, next to the trace context
+// written by Inject. Like Inject, it does nothing when carrier is nil.
+func SetEnqueueTime(carrier map[string]string, t time.Time) {
+	if carrier == nil {
+		return
+	}
+	carrier[EnqueueTimeKey] = strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// ConsumerSpanFromCarrier extracts the producer's context from the message carrier and
+// starts a consumer span continuing that trace, linked to the producer span. When the
+// carrier has an EnqueueTimeKey entry, the time spent in the queue is recorded as
+// messaging.latency_ms.
+func ConsumerSpanFromCarrier(
+	ctx context.Context,
+	carrier map[string]string,
+	name string,
+	opts ...oteltrace.SpanStartOption,
+) (context.Context, oteltrace.Span) {
+	ctx = Extract(ctx, carrier)
+
+	startOpts := []oteltrace.SpanStartOption{oteltrace.WithSpanKind(oteltrace.SpanKindConsumer)}
+	if producer := oteltrace.SpanContextFromContext(ctx); producer.IsValid() {
+		startOpts = append(startOpts, oteltrace.WithLinks(oteltrace.Link{SpanContext: producer}))
+	}
+
+	now := time.Now()
+	if enqueued, ok := enqueueTime(carrier); ok {
+		startOpts = append(startOpts, oteltrace.WithAttributes(messagingLatencyKey.Int64(now.Sub(enqueued).Milliseconds())))
+	}
+	startOpts = append(startOpts, oteltrace.WithTimestamp(now))
+
+	return Span(ctx, name, append(startOpts, opts...)...)
+}
+
+func enqueueTime(carrier map[string]string) (time.Time, bool) {
+	value, ok := carrier[EnqueueTimeKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
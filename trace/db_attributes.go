@@ -0,0 +1,45 @@
+package trace
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// DBInfo describes a database call for SetDBAttributes. Empty fields are not recorded.
+type DBInfo struct {
+	System    string // db.system.name, e.g. "postgresql" or "mysql"
+	Name      string // db.namespace, the database or keyspace
+	Operation string // db.operation.name, taken from Statement when empty
+	Statement string // db.query.text, sanitized with SanitizeStatement
+	Table     string // db.collection.name
+}
+
+// SetDBAttributes sets the database semantic convention attributes described by info on
+// span, so hand-rolled data access layers record the same db.* attributes as the
+// built-in integrations.
+func SetDBAttributes(span oteltrace.Span, info DBInfo) {
+	var attrs []attribute.KeyValue
+	if info.System != "" {
+		attrs = append(attrs, semconv.DBSystemNameKey.String(info.System))
+	}
+	if info.Name != "" {
+		attrs = append(attrs, semconv.DBNamespace(info.Name))
+	}
+
+	operation := info.Operation
+	if operation == "" {
+		operation = statementOperation(info.Statement)
+	}
+	if operation != "" {
+		attrs = append(attrs, semconv.DBOperationName(operation))
+	}
+	if info.Statement != "" {
+		attrs = append(attrs, semconv.DBQueryText(SanitizeStatement(info.Statement)))
+	}
+	if info.Table != "" {
+		attrs = append(attrs, semconv.DBCollectionName(info.Table))
+	}
+
+	span.SetAttributes(attrs...)
+}
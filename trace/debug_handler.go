@@ -0,0 +1,97 @@
+package trace
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// DebugState is the tracing state reported by DebugHandler.
+type DebugState struct {
+	Initialized  bool         `json:"initialized"`
+	Strict       bool         `json:"strict"`
+	AppName      string       `json:"app_name,omitempty"`
+	AppVersion   string       `json:"app_version,omitempty"`
+	TraceEnabled bool         `json:"trace_enabled"`
+	ExporterType string       `json:"exporter_type,omitempty"`
+	Endpoint     string       `json:"endpoint,omitempty"`
+	HeaderNames  []string     `json:"header_names,omitempty"` // values are not reported
+	SampleRate   float64      `json:"sample_rate"`
+	Sampler      string       `json:"sampler,omitempty"`
+	SyncExport   bool         `json:"sync_export"`
+	BatchTimeout string       `json:"batch_timeout"`
+	MaxBatchSize int          `json:"max_batch_size"`
+	MaxQueueSize int          `json:"max_queue_size"`
+	Queue        *QueueState  `json:"queue,omitempty"`
+	Sampling     SamplerStats `json:"sampling"`
+
+	// ExternalProvider is set after InitializeWithProvider, the configuration fields are empty then
+	ExternalProvider bool `json:"external_provider,omitempty"`
+	// PropagationOnly is set after InitializePropagationOnly
+	PropagationOnly bool `json:"propagation_only,omitempty"`
+}
+
+// QueueState reports the export pipeline, available with TracerConfig.PipelineMetrics.
+type QueueState struct {
+	InFlightSpans int64   `json:"in_flight_spans"`
+	QueuedSpans   int64   `json:"queued_spans"`
+	Utilization   float64 `json:"utilization"`
+}
+
+// Debug returns the current tracing state, see DebugHandler.
+func Debug() DebugState {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	state := DebugState{
+		Initialized: initialized,
+		Strict:      strictMode,
+	}
+	if !initialized {
+		return state
+	}
+
+	config := globalConfig
+	state.AppName = config.AppName
+	state.AppVersion = config.AppVersion
+	state.TraceEnabled = config.TraceEnabled
+	state.ExporterType = config.ExporterType.String()
+	state.Endpoint = config.TraceURL
+	state.HeaderNames = slices.Sorted(maps.Keys(config.Headers))
+	if globalSamplingControl != nil {
+		state.SampleRate = globalSamplingControl.sampleRate(config)
+		state.Sampler = globalSamplingControl.sampler.Description()
+	}
+	state.ExternalProvider = globalExternalProvider != nil && !globalPropagationOnly
+	state.PropagationOnly = globalPropagationOnly
+	state.SyncExport = config.SyncExport
+	state.BatchTimeout = config.BatchTimeout.String()
+	state.MaxBatchSize = config.MaxBatchSize
+	state.MaxQueueSize = config.MaxQueueSize
+
+	if globalPipelineMetrics != nil {
+		state.Queue = &QueueState{
+			InFlightSpans: globalPipelineMetrics.inFlight.Load(),
+			QueuedSpans:   max(globalPipelineMetrics.queued.Load(), 0),
+			Utilization:   globalPipelineMetrics.utilization(),
+		}
+	}
+	if globalSamplerStats != nil {
+		state.Sampling = globalSamplerStats.snapshot()
+	}
+	return state
+}
+
+// DebugHandler returns an http.Handler reporting the tracing configuration, sampler,
+// exporter endpoint, initialization state, queue and sampling counters as JSON, to help
+// diagnose missing traces. Header values and credentials are never included. Mount it
+// on an internal mux only.
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(Debug())
+	})
+}
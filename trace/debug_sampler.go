@@ -0,0 +1,80 @@
+package trace
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const forcedSamplingKey = attribute.Key("sampling.forced")
+
+type forceSampleKey struct{}
+
+// DebugHeader returns the name of the request header that forces sampling,
+// or an empty string when the feature is disabled or the tracer is not initialized.
+func DebugHeader() string {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return globalDebugHeader
+}
+
+// ContextFromDebugHeader marks ctx for forced sampling when the configured debug header
+// is set to a truthy value ("1", "true", "yes", "on"). get looks up a header value,
+// e.g. http.Header.Get. Returns ctx unchanged when no debug header is configured.
+func ContextFromDebugHeader(ctx context.Context, get func(key string) string) context.Context {
+	header := DebugHeader()
+	if header == "" || !isTruthy(get(header)) {
+		return ctx
+	}
+	return ForceSample(ctx)
+}
+
+// ForceSample marks ctx so that spans started from it, and their descendants, are sampled
+// and recorded whatever the sample rates, e.g. once an admin user is detected. Call it
+// before starting the root span: spans already started keep their sampling decision.
+func ForceSample(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceSampleKey{}, true)
+}
+
+// isForceSampled reports whether ctx was marked for forced sampling.
+func isForceSampled(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceSampleKey{}).(bool)
+	return forced
+}
+
+func isTruthy(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// forceSampler records and samples every span whose parent context was marked for
+// forced sampling, delegating all other decisions to the fallback sampler.
+type forceSampler struct {
+	fallback sdktrace.Sampler
+}
+
+func newForceSampler(fallback sdktrace.Sampler) sdktrace.Sampler {
+	return &forceSampler{fallback: fallback}
+}
+
+func (s *forceSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if !isForceSampled(p.ParentContext) {
+		return s.fallback.ShouldSample(p)
+	}
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordAndSample,
+		Attributes: []attribute.KeyValue{forcedSamplingKey.Bool(true)},
+		Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s *forceSampler) Description() string {
+	return "ForceSampler{" + s.fallback.Description() + "}"
+}
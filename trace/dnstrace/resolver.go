@@ -0,0 +1,200 @@
+// Package dnstrace wraps a net.Resolver so that DNS lookups emit client spans through the
+// global tracer configured by the trace package, with the queried name, the record type
+// and the answers, to tell DNS latency apart from the latency of the calls that follow.
+package dnstrace
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"strconv"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	spanName = "DNS lookup"
+
+	recordA     = "A"
+	recordAAAA  = "AAAA"
+	recordIP    = "A+AAAA"
+	recordCNAME = "CNAME"
+	recordMX    = "MX"
+	recordTXT   = "TXT"
+	recordSRV   = "SRV"
+	recordNS    = "NS"
+	recordPTR   = "PTR"
+
+	questionTypeKey  = attribute.Key("dns.question.type")
+	answerCountKey   = attribute.Key("dns.answers.count")
+	errorNotFoundKey = attribute.Key("dns.error.not_found")
+	errorTimeoutKey  = attribute.Key("dns.error.timeout")
+)
+
+// Resolver wraps a *net.Resolver and traces every lookup.
+// Methods mirror the net.Resolver API.
+type Resolver struct {
+	resolver *net.Resolver
+}
+
+// NewResolver wraps the given resolver, net.DefaultResolver when nil.
+func NewResolver(resolver *net.Resolver) *Resolver {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Resolver{resolver: resolver}
+}
+
+// Unwrap returns the underlying resolver.
+func (r *Resolver) Unwrap() *net.Resolver {
+	return r.resolver
+}
+
+// LookupHost looks up the addresses of host.
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return lookup(ctx, recordIP, host, func(ctx context.Context) ([]string, error) {
+		return r.resolver.LookupHost(ctx, host)
+	}, func(addr string) string { return addr })
+}
+
+// LookupIPAddr looks up the IP addresses of host.
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return lookup(ctx, recordIP, host, func(ctx context.Context) ([]net.IPAddr, error) {
+		return r.resolver.LookupIPAddr(ctx, host)
+	}, func(addr net.IPAddr) string { return addr.String() })
+}
+
+// LookupIP looks up the IP addresses of host for the network "ip", "ip4" or "ip6".
+func (r *Resolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	return lookup(ctx, ipRecordType(network), host, func(ctx context.Context) ([]net.IP, error) {
+		return r.resolver.LookupIP(ctx, network, host)
+	}, net.IP.String)
+}
+
+// LookupNetIP looks up the IP addresses of host for the network "ip", "ip4" or "ip6".
+func (r *Resolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	return lookup(ctx, ipRecordType(network), host, func(ctx context.Context) ([]netip.Addr, error) {
+		return r.resolver.LookupNetIP(ctx, network, host)
+	}, netip.Addr.String)
+}
+
+// LookupCNAME looks up the canonical name of host.
+func (r *Resolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	cnames, err := lookup(ctx, recordCNAME, host, func(ctx context.Context) ([]string, error) {
+		cname, err := r.resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return []string{cname}, nil
+	}, func(cname string) string { return cname })
+	if err != nil {
+		return "", err
+	}
+	return cnames[0], nil
+}
+
+// LookupMX looks up the mail exchangers of name.
+func (r *Resolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return lookup(ctx, recordMX, name, func(ctx context.Context) ([]*net.MX, error) {
+		return r.resolver.LookupMX(ctx, name)
+	}, func(mx *net.MX) string { return strconv.Itoa(int(mx.Pref)) + " " + mx.Host })
+}
+
+// LookupTXT looks up the text records of name. Their content is not recorded, only their
+// count, as TXT records often hold verification tokens.
+func (r *Resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return lookup(ctx, recordTXT, name, func(ctx context.Context) ([]string, error) {
+		return r.resolver.LookupTXT(ctx, name)
+	}, nil)
+}
+
+// LookupSRV looks up the SRV records of the service, like net.Resolver.LookupSRV.
+func (r *Resolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	question := name
+	if service != "" || proto != "" {
+		question = "_" + service + "._" + proto + "." + name
+	}
+
+	var cname string
+	addrs, err := lookup(ctx, recordSRV, question, func(ctx context.Context) ([]*net.SRV, error) {
+		var addrs []*net.SRV
+		var err error
+		cname, addrs, err = r.resolver.LookupSRV(ctx, service, proto, name)
+		return addrs, err
+	}, func(srv *net.SRV) string {
+		return net.JoinHostPort(srv.Target, strconv.Itoa(int(srv.Port)))
+	})
+	return cname, addrs, err
+}
+
+// LookupNS looks up the name servers of name.
+func (r *Resolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return lookup(ctx, recordNS, name, func(ctx context.Context) ([]*net.NS, error) {
+		return r.resolver.LookupNS(ctx, name)
+	}, func(ns *net.NS) string { return ns.Host })
+}
+
+// LookupAddr looks up the names of addr with a reverse lookup.
+func (r *Resolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	return lookup(ctx, recordPTR, addr, func(ctx context.Context) ([]string, error) {
+		return r.resolver.LookupAddr(ctx, addr)
+	}, func(name string) string { return name })
+}
+
+// lookup runs fn within a client span for the question and records the answers, rendered
+// with answer, or only their count when answer is nil.
+func lookup[T any](
+	ctx context.Context,
+	recordType, question string,
+	fn func(ctx context.Context) ([]T, error),
+	answer func(T) string,
+) ([]T, error) {
+	ctx, span := trace.Span(ctx, spanName,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			semconv.DNSQuestionName(question),
+			questionTypeKey.String(recordType),
+		),
+	)
+	defer span.End()
+
+	answers, err := fn(ctx)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) {
+			span.SetAttributes(
+				errorNotFoundKey.Bool(dnsErr.IsNotFound),
+				errorTimeoutKey.Bool(dnsErr.IsTimeout),
+			)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return answers, err
+	}
+
+	span.SetAttributes(answerCountKey.Int(len(answers)))
+	if answer != nil {
+		values := make([]string, len(answers))
+		for i, a := range answers {
+			values[i] = answer(a)
+		}
+		span.SetAttributes(semconv.DNSAnswers(values...))
+	}
+	return answers, nil
+}
+
+func ipRecordType(network string) string {
+	switch network {
+	case "ip4":
+		return recordA
+	case "ip6":
+		return recordAAAA
+	default:
+		return recordIP
+	}
+}
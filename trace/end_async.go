@@ -0,0 +1,107 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	asyncEndWorkers   = 16
+	asyncEndQueueSize = 1024
+	asyncEndPollDelay = 10 * time.Millisecond
+
+	finalizersSkippedKey = attribute.Key("otel.finalizers.skipped")
+)
+
+// asyncEnd is a span waiting for its finalizers.
+type asyncEnd struct {
+	span       oteltrace.Span
+	finalizers []func(oteltrace.Span)
+}
+
+// asyncEnder runs span finalizers on a fixed number of workers, started on first use.
+type asyncEnder struct {
+	start   sync.Once
+	queue   chan asyncEnd
+	pending atomic.Int64
+}
+
+var globalAsyncEnder = &asyncEnder{queue: make(chan asyncEnd, asyncEndQueueSize)}
+
+// EndAsync runs the finalizers in order on a background worker, then ends span, for
+// results that arrive after the handler returns, e.g. waiting on a channel to record the
+// outcome. Finalizers run on a pool of 16 workers so slow ones cannot pile up goroutines;
+// when 1024 spans are already waiting, span is ended at once without running them and
+// gets otel.finalizers.skipped=true. A panic in a finalizer is recovered and recorded on
+// span as an error wrapping ErrFinalizerPanic. Shutdown waits for pending finalizers.
+//
+//	ctx, span := trace.Span(ctx, "submit-job")
+//	result := submit(ctx)
+//	trace.EndAsync(span, func(span oteltrace.Span) {
+//		if err := <-result; err != nil {
+//			span.RecordError(err)
+//		}
+//	})
+func EndAsync(span oteltrace.Span, finalizers ...func(oteltrace.Span)) {
+	if len(finalizers) == 0 || !span.IsRecording() {
+		span.End()
+		return
+	}
+	globalAsyncEnder.enqueue(asyncEnd{span: span, finalizers: finalizers})
+}
+
+func (e *asyncEnder) enqueue(end asyncEnd) {
+	e.start.Do(func() {
+		for range asyncEndWorkers {
+			go e.work()
+		}
+	})
+
+	e.pending.Add(1)
+	select {
+	case e.queue <- end:
+	default:
+		e.pending.Add(-1)
+		end.span.SetAttributes(finalizersSkippedKey.Bool(true))
+		end.span.End()
+	}
+}
+
+func (e *asyncEnder) work() {
+	for end := range e.queue {
+		for _, finalize := range end.finalizers {
+			runFinalizer(end.span, finalize)
+		}
+		end.span.End()
+		e.pending.Add(-1)
+	}
+}
+
+func runFinalizer(span oteltrace.Span, finalize func(oteltrace.Span)) {
+	defer func() {
+		if r := recover(); r != nil {
+			span.RecordError(fmt.Errorf("%w: %v", ErrFinalizerPanic, r), oteltrace.WithStackTrace(true))
+		}
+	}()
+	finalize(span)
+}
+
+// wait waits for the pending finalizers, or until ctx is done.
+func (e *asyncEnder) wait(ctx context.Context) {
+	ticker := time.NewTicker(asyncEndPollDelay)
+	defer ticker.Stop()
+
+	for e.pending.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// probeEndpoint checks that the OTLP collector at config.TraceURL is reachable the way the
+// exporter will reach it: the host resolves, accepts TCP connections, completes a TLS
+// handshake unless Insecure, and, for OTLP/HTTP, accepts an empty export request on the
+// traces path. Other exporters are not probed.
+func probeEndpoint(ctx context.Context, config TracerConfig) error {
+	if !config.ExporterType.IsGRPC() && !config.ExporterType.IsHTTP() {
+		return nil
+	}
+
+	addr := config.TraceURL
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		host, port = addr, "443"
+		if config.Insecure {
+			port = "80"
+		}
+		addr = net.JoinHostPort(host, port)
+	}
+
+	if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEndpointDNS, host, err)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return fmt.Errorf("%w: %s: %w", ErrEndpointRefused, addr, err)
+		}
+		return fmt.Errorf("%w: %s: %w", ErrEndpointUnreachable, addr, err)
+	}
+	defer conn.Close()
+
+	if !config.Insecure {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEndpointTLS, addr, err)
+		}
+	}
+
+	if config.ExporterType.IsHTTP() {
+		return probeHTTPEndpoint(ctx, config)
+	}
+	return nil
+}
+
+// probeHTTPEndpoint posts an empty export request, which collectors accept, to tell a
+// wrong URLPath or missing credentials apart from a working endpoint.
+func probeHTTPEndpoint(ctx context.Context, config TracerConfig) error {
+	scheme := "https://"
+	if config.Insecure {
+		scheme = "http://"
+	}
+	path := httpTracesPath
+	if config.URLPath != "" {
+		path = config.URLPath
+	}
+	url := scheme + config.TraceURL + path
+
+	contentType, body := "application/x-protobuf", []byte(nil)
+	if config.HTTPJSON {
+		contentType, body = "application/json", []byte("{}")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEndpointUnreachable, url, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEndpointUnreachable, url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrEndpointNotFound, url)
+	case resp.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("%w: %s: %s", ErrEndpointRejected, url, resp.Status)
+	}
+	return nil
+}
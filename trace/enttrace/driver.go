@@ -0,0 +1,138 @@
+// Package enttrace traces ent through the global tracer configured by the trace package:
+// a dialect.Driver wrapper creating a client span per statement, and a hook creating a
+// span per mutation, both with the entity type and operation.
+package enttrace
+
+import (
+	"context"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSpanName = "ent"
+
+	entityTypeKey = attribute.Key("ent.entity.type")
+	operationKey  = attribute.Key("ent.operation")
+)
+
+// dialectSystems maps ent dialects to db.system.name values.
+var dialectSystems = map[string]string{
+	dialect.MySQL:    "mysql",
+	dialect.SQLite:   "sqlite",
+	dialect.Postgres: "postgresql",
+	dialect.Gremlin:  "gremlin",
+}
+
+// Driver wraps a dialect.Driver and traces every statement, including those of
+// transactions. Queries carry the entity type and operation (All, First, Count, ...) of
+// the ent query that issued them.
+type Driver struct {
+	dialect.Driver
+}
+
+// NewDriver wraps drv, e.g. ent.NewClient(ent.Driver(enttrace.NewDriver(drv))).
+func NewDriver(drv dialect.Driver) *Driver {
+	return &Driver{Driver: drv}
+}
+
+// Exec executes the statement within a client span.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	return exec(ctx, d.Dialect(), query, func(ctx context.Context) error {
+		return d.Driver.Exec(ctx, query, args, v)
+	})
+}
+
+// Query executes the query within a client span.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	return exec(ctx, d.Dialect(), query, func(ctx context.Context) error {
+		return d.Driver.Query(ctx, query, args, v)
+	})
+}
+
+// Tx starts a transaction whose statements are traced.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: d.Dialect()}, nil
+}
+
+// Tx is a transaction of a Driver.
+type Tx struct {
+	dialect.Tx
+	dialect string
+}
+
+// Exec executes the statement within a client span.
+func (tx *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	return exec(ctx, tx.dialect, query, func(ctx context.Context) error {
+		return tx.Tx.Exec(ctx, query, args, v)
+	})
+}
+
+// Query executes the query within a client span.
+func (tx *Tx) Query(ctx context.Context, query string, args, v any) error {
+	return exec(ctx, tx.dialect, query, func(ctx context.Context) error {
+		return tx.Tx.Query(ctx, query, args, v)
+	})
+}
+
+// exec runs fn within a client span named after the statement operation and the entity
+// type of the ent query in ctx, e.g. "SELECT User".
+func exec(ctx context.Context, dialectName, query string, fn func(ctx context.Context) error) error {
+	var attrs []attribute.KeyValue
+	var entityType string
+	if q := ent.QueryFromContext(ctx); q != nil {
+		entityType = q.Type
+		attrs = append(attrs, entityTypeKey.String(q.Type), operationKey.String(q.Op))
+	}
+
+	operation := operationName(query)
+	ctx, span := trace.Span(ctx, spanName(operation, entityType),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	trace.SetDBAttributes(span, trace.DBInfo{
+		System:    dialectSystem(dialectName),
+		Operation: operation,
+		Statement: query,
+	})
+
+	err := fn(ctx)
+	trace.RecordError(ctx, err)
+	return err
+}
+
+// operationName extracts the SQL command (SELECT, INSERT, ...) from a statement.
+func operationName(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// spanName builds a low-cardinality span name such as "SELECT User".
+func spanName(operation, entityType string) string {
+	name := strings.TrimSpace(operation + " " + entityType)
+	if name == "" {
+		return defaultSpanName
+	}
+	return name
+}
+
+func dialectSystem(dialectName string) string {
+	if system, ok := dialectSystems[dialectName]; ok {
+		return system
+	}
+	return dialectName
+}
@@ -0,0 +1,30 @@
+package enttrace
+
+import (
+	"context"
+	"strings"
+
+	"entgo.io/ent"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Hook returns an ent hook running every mutation within a span such as "User.Create",
+// with the entity type and operation, so the statements of the mutation nest under it.
+// Register it with client.Use(enttrace.Hook()).
+func Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			operation := strings.TrimPrefix(m.Op().String(), "Op")
+			ctx, span := trace.Span(ctx, m.Type()+"."+operation, oteltrace.WithAttributes(
+				entityTypeKey.String(m.Type()),
+				operationKey.String(operation),
+			))
+			defer span.End()
+
+			value, err := next.Mutate(ctx, m)
+			trace.RecordError(ctx, err)
+			return value, err
+		})
+	}
+}
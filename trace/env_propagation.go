@@ -0,0 +1,59 @@
+package trace
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// envCarrier adapts environment variables to propagation.TextMapCarrier. Keys are
+// upper-cased, so traceparent is carried in TRACEPARENT as other OpenTelemetry SDKs expect.
+type envCarrier map[string]string
+
+var _ propagation.TextMapCarrier = envCarrier{}
+
+func (c envCarrier) Get(key string) string {
+	return c[strings.ToUpper(key)]
+}
+
+func (c envCarrier) Set(key, value string) {
+	c[strings.ToUpper(key)] = value
+}
+
+func (c envCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// EnvFromContext returns the trace context and baggage of ctx as KEY=value environment
+// entries (TRACEPARENT, TRACESTATE, BAGGAGE) for child processes:
+//
+//	cmd := exec.CommandContext(ctx, "worker")
+//	cmd.Env = append(os.Environ(), trace.EnvFromContext(ctx)...)
+func EnvFromContext(ctx context.Context) []string {
+	carrier := envCarrier{}
+	InjectCarrier(ctx, carrier)
+
+	env := make([]string, 0, len(carrier))
+	for key, value := range carrier {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// ContextFromEnv returns a copy of ctx with the trace context and baggage read from the
+// process environment, so spans of a child process join the parent's trace. Call it
+// after Initialize, which installs the propagator.
+func ContextFromEnv(ctx context.Context) context.Context {
+	carrier := envCarrier{}
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		carrier[strings.ToUpper(key)] = value
+	}
+	return ExtractCarrier(ctx, carrier)
+}
@@ -0,0 +1,75 @@
+package trace
+
+import "fmt"
+
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "production"
+
+	defaultCollectorURL = "localhost:4317"
+)
+
+// ConfigOverride adjusts the configuration selected by InitializeForEnvironment.
+type ConfigOverride func(*TracerConfig)
+
+// InitializeForEnvironment initializes the tracer with defaults for the environment and
+// then applies the overrides, which must at least set AppName:
+//
+//   - development: stdout exporter, synchronous export, every trace sampled
+//   - staging: OTLP/gRPC to an insecure local collector (localhost:4317), every trace sampled
+//   - production: OTLP/gRPC to an insecure local collector (localhost:4317), 10% of traces
+//     sampled with consistent probability sampling
+//
+// Overrides run after the defaults, e.g. to point TraceURL at a remote collector.
+func InitializeForEnvironment(env string, overrides ...ConfigOverride) error {
+	config, err := EnvironmentConfig(env)
+	if err != nil {
+		return err
+	}
+	for _, override := range overrides {
+		override(&config)
+	}
+	return Initialize(config)
+}
+
+// EnvironmentConfig returns the defaults InitializeForEnvironment uses for env.
+func EnvironmentConfig(env string) (TracerConfig, error) {
+	switch env {
+	case EnvironmentDevelopment:
+		return TracerConfig{
+			TraceEnabled: true,
+			ExporterType: mustExporterType(ExporterTypeStdout),
+			SampleRate:   1.0,
+			SyncExport:   true,
+		}, nil
+	case EnvironmentStaging:
+		return TracerConfig{
+			TraceEnabled: true,
+			ExporterType: mustExporterType(ExporterTypeGRPC),
+			TraceURL:     defaultCollectorURL,
+			Insecure:     true,
+			SampleRate:   1.0,
+		}, nil
+	case EnvironmentProduction:
+		return TracerConfig{
+			TraceEnabled: true,
+			ExporterType: mustExporterType(ExporterTypeGRPC),
+			TraceURL:     defaultCollectorURL,
+			Insecure:     true,
+			SampleRate:   0.1,
+			// plain ratios of 0.01 and above sample every trace, see newSampler
+			ConsistentSampling: true,
+		}, nil
+	default:
+		return TracerConfig{}, fmt.Errorf("%w: %s", ErrInvalidEnvironment, env)
+	}
+}
+
+// WithAppName sets the service name and version.
+func WithAppName(name, version string) ConfigOverride {
+	return func(c *TracerConfig) {
+		c.AppName = name
+		c.AppVersion = version
+	}
+}
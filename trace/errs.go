@@ -3,20 +3,64 @@ package trace
 import "errors"
 
 var (
-	ErrAppNameRequired     = errors.New("AppName is required")
-	ErrTraceURLRequired    = errors.New("TraceURL is required when tracing is enabled")
-	ErrInvalidSampleRate   = errors.New("SampleRate must be between 0.0 and 1.0")
-	ErrInvalidExporterType = errors.New("invalid exporter type (must be 'grpc' or 'http')")
+	ErrAppNameRequired           = errors.New("AppName is required")
+	ErrTraceURLRequired          = errors.New("TraceURL is required when tracing is enabled")
+	ErrInvalidSampleRate         = errors.New("SampleRate must be between 0.0 and 1.0")
+	ErrInvalidSampleRateSchedule = errors.New("SampleRateSchedule steps must have a positive Duration")
+	ErrInvalidExporterType       = errors.New("invalid exporter type (must be 'grpc', 'http', 'kafka', 'googlecloud', 'azuremonitor' or 'stdout')")
 
-	ErrAlreadyInitialized   = errors.New("tracer already initialized")
-	ErrNotInitialized       = errors.New("tracer not initialized")
-	ErrCreateTracerProvider = errors.New("failed to create tracer provider")
-	ErrCreateExporter       = errors.New("failed to create exporter")
+	ErrKafkaBrokersRequired = errors.New("KafkaBrokers is required when using the kafka exporter")
+	ErrKafkaTopicRequired   = errors.New("KafkaTopic is required when using the kafka exporter")
+
+	ErrAzureConnectionStringRequired = errors.New("AzureConnectionString is required when using the azuremonitor exporter")
+	ErrInvalidAzureConnectionString  = errors.New("AzureConnectionString must contain an InstrumentationKey")
+	ErrInvalidSpanNameRule           = errors.New("invalid span name rule")
+
+	ErrInvalidSemconvStability     = errors.New("invalid semconv stability (must be 'stable', 'legacy' or 'dup')")
+	ErrInvalidStatementObfuscation = errors.New("invalid statement obfuscation (must be 'none', 'literals' or 'full')")
+	ErrInvalidProfile              = errors.New("invalid profile (must be 'low_latency', 'high_throughput' or 'serverless')")
+	ErrInvalidTraceParent          = errors.New("invalid traceparent")
+
+	ErrAlreadyInitialized         = errors.New("tracer already initialized")
+	ErrInitializedWithOtherConfig = errors.New("tracer already initialized with a different configuration")
+	ErrNotInitialized             = errors.New("tracer not initialized")
+	ErrCreateTracerProvider       = errors.New("failed to create tracer provider")
+	ErrTracerProviderRequired     = errors.New("tracer provider is required")
+	ErrExternalTracerProvider     = errors.New("not supported with a tracer provider created by the application")
+	ErrPropagationOnly            = errors.New("not supported in propagation-only mode")
+	ErrReloadUnsupported          = errors.New("configuration fields cannot be reloaded, Shutdown and Initialize instead")
+	ErrCreateExporter             = errors.New("failed to create exporter")
+
+	ErrEndpointDNS         = errors.New("collector endpoint host does not resolve")
+	ErrEndpointRefused     = errors.New("collector endpoint refused the connection")
+	ErrEndpointUnreachable = errors.New("collector endpoint is unreachable")
+	ErrEndpointTLS         = errors.New("collector endpoint TLS handshake failed")
+	ErrEndpointNotFound    = errors.New("collector endpoint returned 404, check URLPath")
+	ErrEndpointRejected    = errors.New("collector endpoint rejected the export request")
 
 	ErrCreateGRPCExporter = errors.New("failed to create OTLP gRPC exporter")
 	ErrCreateHTTPExporter = errors.New("failed to create OTLP HTTP exporter")
 
+	ErrCreateStdoutExporter = errors.New("failed to create stdout exporter")
+	ErrInvalidEnvironment   = errors.New("invalid environment (must be 'development', 'staging' or 'production')")
+
+	ErrCreateKafkaExporter = errors.New("failed to create Kafka exporter")
+	ErrMarshalSpans        = errors.New("failed to marshal spans")
+	ErrPublishSpans        = errors.New("failed to publish spans to Kafka")
+	ErrSendSpans           = errors.New("failed to send spans")
+
+	ErrCreateGoogleCloudExporter = errors.New("failed to create Google Cloud Trace exporter")
+	ErrGoogleCloudCredentials    = errors.New("failed to find Google Application Default Credentials")
+	ErrGCPProjectIDRequired      = errors.New("GCPProjectID is required when credentials do not provide a project")
+
+	ErrCreateAzureMonitorExporter = errors.New("failed to create Azure Monitor exporter")
+
+	ErrTracerProviderFlush    = errors.New("tracer provider flush failed")
 	ErrTracerProviderShutdown = errors.New("tracer provider shutdown failed")
 	ErrExporterShutdown       = errors.New("exporter shutdown failed")
 	ErrMultipleShutdown       = errors.New("multiple shutdown failures")
+	ErrShutdownHook           = errors.New("shutdown hook failed")
+
+	ErrTaskPanic      = errors.New("task panicked")
+	ErrFinalizerPanic = errors.New("span finalizer panicked")
 )
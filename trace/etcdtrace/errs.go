@@ -0,0 +1,5 @@
+package etcdtrace
+
+import "errors"
+
+var ErrCreateClient = errors.New("failed to create etcd client")
@@ -0,0 +1,106 @@
+// Package etcdtrace wires the grpctrace interceptors into etcd clientv3 so that
+// key-value requests and watches appear in traces.
+package etcdtrace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/grpctrace"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+const (
+	watchSpanName  = "etcd watch"
+	watchEventName = "etcd.watch.response"
+
+	dbSystemKey      = attribute.Key("db.system.name")
+	dbSystemEtcd     = "etcd"
+	watchKeyKey      = attribute.Key("db.etcd.watch.key")
+	eventCountKey    = attribute.Key("db.etcd.watch.event_count")
+	revisionKey      = attribute.Key("db.etcd.revision")
+	responseCountKey = attribute.Key("db.etcd.watch.response_count")
+)
+
+// Configure appends the tracing interceptors to the dial options of the etcd configuration.
+func Configure(cfg *clientv3.Config) {
+	cfg.DialOptions = append(cfg.DialOptions,
+		grpc.WithChainUnaryInterceptor(grpctrace.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(grpctrace.StreamClientInterceptor()),
+	)
+}
+
+// NewClient creates an etcd client with tracing enabled.
+// The client's Watcher is replaced by a traced watcher, see NewWatcher.
+func NewClient(cfg clientv3.Config) (*clientv3.Client, error) {
+	Configure(&cfg)
+
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateClient, err)
+	}
+
+	client.Watcher = NewWatcher(client.Watcher)
+	return client, nil
+}
+
+// Watcher wraps a clientv3.Watcher and records a span per watch.
+// Each watch response is added to the span as an event; the span ends when the
+// watch channel is closed (context canceled or watcher closed).
+type Watcher struct {
+	clientv3.Watcher
+}
+
+// NewWatcher wraps the given watcher.
+func NewWatcher(w clientv3.Watcher) *Watcher {
+	return &Watcher{Watcher: w}
+}
+
+// Watch watches on a key or prefix and traces the lifetime of the watch.
+func (w *Watcher) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	ctx, span := trace.Span(
+		ctx,
+		watchSpanName,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			dbSystemKey.String(dbSystemEtcd),
+			watchKeyKey.String(key),
+		),
+	)
+
+	upstream := w.Watcher.Watch(ctx, key, opts...)
+	out := make(chan clientv3.WatchResponse)
+
+	go func() {
+		responses := 0
+		defer func() {
+			close(out)
+			span.SetAttributes(responseCountKey.Int(responses))
+			span.End()
+		}()
+
+		for resp := range upstream {
+			responses++
+			span.AddEvent(watchEventName, oteltrace.WithAttributes(
+				eventCountKey.Int(len(resp.Events)),
+				revisionKey.Int64(resp.Header.Revision),
+			))
+			if err := resp.Err(); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			select {
+			case out <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
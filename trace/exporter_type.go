@@ -3,8 +3,15 @@ package trace
 import "fmt"
 
 const (
-	ExporterTypeGRPC = "grpc"
-	ExporterTypeHTTP = "http"
+	ExporterTypeGRPC  = "grpc"
+	ExporterTypeHTTP  = "http"
+	ExporterTypeKafka = "kafka"
+	// ExporterTypeGoogleCloud sends spans to Cloud Trace through the Google Cloud Telemetry API
+	ExporterTypeGoogleCloud = "googlecloud"
+	// ExporterTypeAzureMonitor sends spans to Azure Monitor Application Insights
+	ExporterTypeAzureMonitor = "azuremonitor"
+	// ExporterTypeStdout writes spans as JSON to standard output, for local development
+	ExporterTypeStdout = "stdout"
 )
 
 type ExporterType struct {
@@ -13,7 +20,8 @@ type ExporterType struct {
 
 func NewExporterType(value string) (ExporterType, error) {
 	switch value {
-	case ExporterTypeGRPC, ExporterTypeHTTP:
+	case ExporterTypeGRPC, ExporterTypeHTTP, ExporterTypeKafka, ExporterTypeGoogleCloud, ExporterTypeAzureMonitor,
+		ExporterTypeStdout:
 		return ExporterType{value: value}, nil
 	default:
 		return ExporterType{}, fmt.Errorf("%w: %s", ErrInvalidExporterType, value)
@@ -32,6 +40,22 @@ func (e ExporterType) IsHTTP() bool {
 	return e.value == ExporterTypeHTTP
 }
 
+func (e ExporterType) IsKafka() bool {
+	return e.value == ExporterTypeKafka
+}
+
+func (e ExporterType) IsGoogleCloud() bool {
+	return e.value == ExporterTypeGoogleCloud
+}
+
+func (e ExporterType) IsAzureMonitor() bool {
+	return e.value == ExporterTypeAzureMonitor
+}
+
 func (e ExporterType) IsZero() bool {
 	return e.value == ""
 }
+
+func (e ExporterType) IsStdout() bool {
+	return e.value == ExporterTypeStdout
+}
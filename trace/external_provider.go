@@ -0,0 +1,67 @@
+package trace
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// externalTracerName is the instrumentation scope of spans created via Span on an
+// external provider, which has no AppName to use instead.
+const externalTracerName = "github.com/cristiano-pacheco/go-otel/trace"
+
+// flusher is implemented by providers supporting ForceFlush, e.g. *sdktrace.TracerProvider.
+type flusher interface {
+	ForceFlush(ctx context.Context) error
+}
+
+// InitializeWithProvider initializes the package on top of a tracer provider created by
+// the application, e.g. by a vendor distribution, so Span, the integrations and the
+// helpers use it. The application keeps ownership: Shutdown does not shut the provider
+// down, and the OpenTelemetry global provider and propagator are left as they are.
+//
+// Sampling, export and the TracerConfig features are the provider's concern, so
+// SetSampleRate and SetTracingEnabled return ErrExternalTracerProvider. OnSpanStart,
+// OnSpanEnd and SetTraceAttribute work when tp is an *sdktrace.TracerProvider.
+func InitializeWithProvider(tp oteltrace.TracerProvider) error {
+	if tp == nil {
+		return ErrTracerProviderRequired
+	}
+
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if initialized {
+		return ErrAlreadyInitialized
+	}
+
+	var attrs *traceAttributes
+	if sdkProvider, ok := tp.(*sdktrace.TracerProvider); ok {
+		attrs = newTraceAttributes()
+		sdkProvider.RegisterSpanProcessor(attrs)
+		sdkProvider.RegisterSpanProcessor(globalHooks)
+	}
+
+	globalTracer = tp.Tracer(externalTracerName)
+	globalExternalProvider = tp
+	globalTraceAttributes = attrs
+	globalSemconvStability = semconvStabilityFromEnv()
+	globalStatementSanitizer = defaultStatementSanitizer
+	globalConfig = TracerConfig{TraceEnabled: true}
+	initialized = true
+
+	return nil
+}
+
+// releaseExternalProvider removes the processors registered by InitializeWithProvider.
+func releaseExternalProvider() {
+	sdkProvider, ok := globalExternalProvider.(*sdktrace.TracerProvider)
+	if !ok {
+		return
+	}
+	if globalTraceAttributes != nil {
+		sdkProvider.UnregisterSpanProcessor(globalTraceAttributes)
+	}
+	sdkProvider.UnregisterSpanProcessor(globalHooks)
+}
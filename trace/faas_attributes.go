@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// FaaS triggers recorded as faas.trigger.
+const (
+	FaaSTriggerDatasource = "datasource"
+	FaaSTriggerHTTP       = "http"
+	FaaSTriggerPubSub     = "pubsub"
+	FaaSTriggerTimer      = "timer"
+	FaaSTriggerOther      = "other"
+)
+
+// FaaSInfo describes a serverless function invocation for SetFaaSAttributes. Empty
+// fields are not recorded.
+type FaaSInfo struct {
+	Trigger      string // faas.trigger, one of the FaaSTrigger constants
+	InvocationID string // faas.invocation_id, e.g. the request ID of the platform
+	ColdStart    bool   // faas.coldstart, see ColdStart
+	Name         string // faas.name, when it differs from the service name
+	Version      string // faas.version
+}
+
+var coldStartConsumed atomic.Bool
+
+// ColdStart reports true on its first call in the process and false afterwards, so
+// handlers can pass ColdStart() as FaaSInfo.ColdStart on every invocation.
+func ColdStart() bool {
+	return !coldStartConsumed.Swap(true)
+}
+
+// SetFaaSAttributes sets the FaaS semantic convention attributes described by info on
+// span. Unknown triggers are recorded as "other".
+func SetFaaSAttributes(span oteltrace.Span, info FaaSInfo) {
+	var attrs []attribute.KeyValue
+	if info.Trigger != "" {
+		attrs = append(attrs, faasTrigger(info.Trigger))
+	}
+	if info.InvocationID != "" {
+		attrs = append(attrs, semconv.FaaSInvocationID(info.InvocationID))
+	}
+	attrs = append(attrs, semconv.FaaSColdstart(info.ColdStart))
+	if info.Name != "" {
+		attrs = append(attrs, semconv.FaaSName(info.Name))
+	}
+	if info.Version != "" {
+		attrs = append(attrs, semconv.FaaSVersion(info.Version))
+	}
+
+	span.SetAttributes(attrs...)
+}
+
+func faasTrigger(trigger string) attribute.KeyValue {
+	switch trigger {
+	case FaaSTriggerDatasource, FaaSTriggerHTTP, FaaSTriggerPubSub, FaaSTriggerTimer:
+		return semconv.FaaSTriggerKey.String(trigger)
+	default:
+		return semconv.FaaSTriggerOther
+	}
+}
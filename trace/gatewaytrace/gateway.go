@@ -0,0 +1,42 @@
+// Package gatewaytrace carries the trace context across the grpc-gateway boundary, from
+// the HTTP request served by the gateway to the gRPC call it makes to the backend.
+package gatewaytrace
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/grpctrace"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServeMuxOption registers Annotator on a runtime.ServeMux.
+func ServeMuxOption() runtime.ServeMuxOption {
+	return runtime.WithMetadata(Annotator)
+}
+
+// Annotator is a grpc-gateway metadata annotator adding the trace context to the metadata
+// of the backend gRPC call, which the gateway does not forward by default. When the
+// gateway is wrapped by the httptrace middleware, the HTTP server span becomes the parent
+// of the gRPC spans and is renamed after the route, e.g. "GET /v1/users/{id}", with
+// http.route. Otherwise the trace context of the request headers is forwarded as is.
+func Annotator(ctx context.Context, r *http.Request) metadata.MD {
+	md := metadata.MD{}
+
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		grpctrace.InjectMetadata(trace.ExtractCarrier(ctx, trace.HTTPHeaderCarrier(r.Header)), md)
+		return md
+	}
+
+	if pattern, ok := runtime.HTTPPathPattern(ctx); ok && span.IsRecording() {
+		span.SetName(r.Method + " " + pattern)
+		span.SetAttributes(semconv.HTTPRoute(pattern))
+	}
+	grpctrace.InjectMetadata(ctx, md)
+	return md
+}
@@ -0,0 +1,132 @@
+// Package gocqltrace provides gocql query and batch observers that emit spans
+// through the global tracer configured by the trace package.
+package gocqltrace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/gocql/gocql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSpanName    = "cassandra"
+	batchOperationName = "BATCH"
+	attemptKey         = attribute.Key("db.cassandra.attempt")
+)
+
+// Observer implements gocql.QueryObserver and gocql.BatchObserver.
+// Register it on a ClusterConfig (QueryObserver and BatchObserver fields) or on individual queries.
+type Observer struct{}
+
+var (
+	_ gocql.QueryObserver = (*Observer)(nil)
+	_ gocql.BatchObserver = (*Observer)(nil)
+)
+
+// NewObserver creates a new gocql observer backed by the global tracer.
+func NewObserver() *Observer {
+	return &Observer{}
+}
+
+// Instrument registers the observer as both query and batch observer on the cluster config.
+func Instrument(cluster *gocql.ClusterConfig) {
+	observer := NewObserver()
+	cluster.QueryObserver = observer
+	cluster.BatchObserver = observer
+}
+
+// ObserveQuery records a span for a single query execution.
+func (o *Observer) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	operation := operationName(q.Statement)
+
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNameCassandra,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(trace.SanitizeStatement(q.Statement)),
+		semconv.DBResponseReturnedRows(q.Rows),
+		attemptKey.Int(q.Attempt),
+	}
+	attrs = append(attrs, commonAttributes(q.Keyspace, q.Host)...)
+
+	o.record(ctx, spanName(operation, q.Keyspace), q.Start, q.End, attrs, q.Err)
+}
+
+// ObserveBatch records a span for a batch execution.
+func (o *Observer) ObserveBatch(ctx context.Context, b gocql.ObservedBatch) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNameCassandra,
+		semconv.DBOperationName(batchOperationName),
+		semconv.DBOperationBatchSize(len(b.Statements)),
+		semconv.DBQueryText(trace.SanitizeStatement(strings.Join(b.Statements, "; "))),
+		attemptKey.Int(b.Attempt),
+	}
+	attrs = append(attrs, commonAttributes(b.Keyspace, b.Host)...)
+
+	o.record(ctx, spanName(batchOperationName, b.Keyspace), b.Start, b.End, attrs, b.Err)
+}
+
+// record starts and ends a client span using the timestamps reported by gocql,
+// so the span duration matches the observed query latency.
+func (o *Observer) record(
+	ctx context.Context,
+	name string,
+	start time.Time,
+	end time.Time,
+	attrs []attribute.KeyValue,
+	err error,
+) {
+	_, span := trace.Span(
+		ctx,
+		name,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithTimestamp(start),
+		oteltrace.WithAttributes(attrs...),
+	)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End(oteltrace.WithTimestamp(end))
+}
+
+// commonAttributes returns the attributes shared by queries and batches.
+func commonAttributes(keyspace string, host *gocql.HostInfo) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if keyspace != "" {
+		attrs = append(attrs, semconv.DBNamespace(keyspace))
+	}
+	if host != nil {
+		attrs = append(attrs,
+			semconv.ServerAddress(host.ConnectAddress().String()),
+			semconv.ServerPort(host.Port()),
+		)
+	}
+	return attrs
+}
+
+// operationName extracts the CQL command (SELECT, INSERT, ...) from a statement.
+func operationName(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// spanName builds a low-cardinality span name such as "SELECT my_keyspace".
+func spanName(operation, keyspace string) string {
+	name := strings.TrimSpace(operation + " " + keyspace)
+	if name == "" {
+		return defaultSpanName
+	}
+	return name
+}
@@ -0,0 +1,140 @@
+package gocqltrace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/tracetest"
+	"github.com/gocql/gocql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+func initTracer(t *testing.T) {
+	t.Helper()
+	exporterType, err := trace.NewExporterType(trace.ExporterTypeStdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = trace.Initialize(trace.TracerConfig{
+		AppName:      "gocqltrace-test",
+		AppVersion:   "1.0.0",
+		TraceEnabled: true,
+		SampleRate:   1,
+		ExporterType: exporterType,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = trace.Shutdown(context.Background()) })
+}
+
+func TestObserver(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(25 * time.Millisecond)
+	errTimeout := errors.New("timeout")
+
+	tests := []struct {
+		name       string
+		observe    func(*Observer)
+		wantName   string
+		want       map[attribute.Key]string
+		wantStatus codes.Code
+	}{
+		{
+			name: "query",
+			observe: func(o *Observer) {
+				o.ObserveQuery(context.Background(), gocql.ObservedQuery{
+					Keyspace:  "shop",
+					Statement: "select * from orders where id = 'o-1'",
+					Start:     start,
+					End:       end,
+					Rows:      1,
+				})
+			},
+			wantName: "SELECT shop",
+			want: map[attribute.Key]string{
+				semconv.DBSystemNameKey:           "cassandra",
+				semconv.DBOperationNameKey:        "SELECT",
+				semconv.DBQueryTextKey:            "select * from orders where id = ?",
+				semconv.DBNamespaceKey:            "shop",
+				semconv.DBResponseReturnedRowsKey: "1",
+			},
+		},
+		{
+			name: "failed query",
+			observe: func(o *Observer) {
+				o.ObserveQuery(context.Background(), gocql.ObservedQuery{
+					Statement: "INSERT INTO orders (id) VALUES (42)",
+					Start:     start,
+					End:       end,
+					Err:       errTimeout,
+					Attempt:   2,
+				})
+			},
+			wantName: "INSERT",
+			want: map[attribute.Key]string{
+				semconv.DBQueryTextKey: "INSERT INTO orders (id) VALUES (?)",
+				attemptKey:             "2",
+			},
+			wantStatus: codes.Error,
+		},
+		{
+			name: "empty statement",
+			observe: func(o *Observer) {
+				o.ObserveQuery(context.Background(), gocql.ObservedQuery{Start: start, End: end})
+			},
+			wantName: defaultSpanName,
+		},
+		{
+			name: "batch",
+			observe: func(o *Observer) {
+				o.ObserveBatch(context.Background(), gocql.ObservedBatch{
+					Keyspace:   "shop",
+					Statements: []string{"UPDATE a SET n = 1", "UPDATE b SET n = 'x'"},
+					Start:      start,
+					End:        end,
+				})
+			},
+			wantName: "BATCH shop",
+			want: map[attribute.Key]string{
+				semconv.DBOperationNameKey:      "BATCH",
+				semconv.DBOperationBatchSizeKey: "2",
+				semconv.DBQueryTextKey:          "UPDATE a SET n = ?; UPDATE b SET n = ?",
+			},
+		},
+	}
+
+	initTracer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.RecordSpans(t)
+			tt.observe(NewObserver())
+
+			spans := recorder.Spans()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantName {
+				t.Errorf("name = %q, want %q", span.Name(), tt.wantName)
+			}
+			if got := span.EndTime().Sub(span.StartTime()); got != end.Sub(start) {
+				t.Errorf("duration = %v, want %v", got, end.Sub(start))
+			}
+			attrs := attribute.NewSet(span.Attributes()...)
+			for key, want := range tt.want {
+				if got, ok := attrs.Value(key); !ok || got.Emit() != want {
+					t.Errorf("%s = %q, want %q", key, got.Emit(), want)
+				}
+			}
+			if got := span.Status().Code; got != tt.wantStatus {
+				t.Errorf("status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/oauth"
+)
+
+const (
+	googleCloudTraceEndpoint = "telemetry.googleapis.com:443"
+	googleCloudTraceScope    = "https://www.googleapis.com/auth/trace.append"
+	googleUserProjectHeader  = "x-goog-user-project"
+
+	// gcpProjectIDKey is the resource attribute the Telemetry API uses to route spans
+	gcpProjectIDKey = attribute.Key("gcp.project_id")
+)
+
+// newGoogleCloudExporter creates an OTLP gRPC exporter sending spans directly to the
+// Google Cloud Telemetry API (Cloud Trace), authenticated with Application Default Credentials
+func newGoogleCloudExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExporter, error) {
+	credentials, err := google.FindDefaultCredentials(ctx, googleCloudTraceScope)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGoogleCloudCredentials, err)
+	}
+
+	projectID := config.GCPProjectID
+	if projectID == "" {
+		projectID = credentials.ProjectID
+	}
+	if projectID == "" {
+		return nil, ErrGCPProjectIDRequired
+	}
+
+	endpoint := config.TraceURL
+	if endpoint == "" {
+		endpoint = googleCloudTraceEndpoint
+	}
+
+	headers := maps.Clone(config.Headers)
+	if headers == nil {
+		headers = make(map[string]string, 1)
+	}
+	headers[googleUserProjectHeader] = projectID
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithHeaders(headers),
+		otlptracegrpc.WithDialOption(
+			grpc.WithPerRPCCredentials(oauth.TokenSource{TokenSource: credentials.TokenSource}),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateGoogleCloudExporter, err)
+	}
+
+	return exporter, nil
+}
@@ -0,0 +1,85 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// TaskGroup runs tasks concurrently like errgroup.Group, with a span per task under the
+// span of the group. Create it with Group.
+type TaskGroup struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	span   oteltrace.Span
+
+	wg       sync.WaitGroup
+	errOnce  sync.Once
+	err      error
+	waitOnce sync.Once
+}
+
+// Group starts a span named name and returns a group whose tasks run as its children, and
+// the group context, which is canceled when a task fails or Wait returns:
+//
+//	g, ctx := trace.Group(ctx, "load-dashboard")
+//	g.Go("load-orders", func(ctx context.Context) error { return loadOrders(ctx) })
+//	g.Go("load-invoices", func(ctx context.Context) error { return loadInvoices(ctx) })
+//	err := g.Wait()
+func Group(ctx context.Context, name string) (*TaskGroup, context.Context) {
+	ctx, span := Span(ctx, name)
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &TaskGroup{ctx: ctx, cancel: cancel, span: span}, ctx
+}
+
+// Go runs fn in a new goroutine with a context carrying a span named taskName. An error
+// returned by fn is recorded on the task span, and the first one cancels the group
+// context and is returned by Wait. A panic in fn is recovered and handled as an error
+// wrapping ErrTaskPanic, with the stack recorded on the task span.
+func (g *TaskGroup) Go(taskName string, fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		ctx, span := Span(g.ctx, taskName)
+		defer span.End()
+
+		if err := runTask(ctx, taskName, fn); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+func runTask(ctx context.Context, taskName string, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrTaskPanic, taskName, r)
+			RecordError(ctx, err, oteltrace.WithStackTrace(true))
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// Wait waits for all tasks, cancels the group context and ends the group span, recording
+// the first error on it. It returns the first error.
+func (g *TaskGroup) Wait() error {
+	g.wg.Wait()
+	g.waitOnce.Do(func() {
+		g.cancel(nil)
+		if g.err != nil {
+			RecordError(oteltrace.ContextWithSpan(g.ctx, g.span), g.err)
+		}
+		g.span.End()
+	})
+	return g.err
+}
@@ -0,0 +1,49 @@
+package grpctrace
+
+import (
+	"context"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+// Get returns the first value associated with the given key.
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set stores the key-value pair, replacing any existing values.
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier.
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectMetadata writes the trace context of ctx into md, e.g. metadata returned by a
+// grpc-gateway annotator or sent by a client without the interceptors.
+func InjectMetadata(ctx context.Context, md metadata.MD) {
+	otel.GetTextMapPropagator().Inject(ctx, MetadataCarrier(md))
+}
+
+// ExtractMetadata returns ctx with the remote trace context read from md, honoring the
+// debug header configured in the trace package.
+func ExtractMetadata(ctx context.Context, md metadata.MD) context.Context {
+	carrier := MetadataCarrier(md)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	return trace.ContextFromDebugHeader(ctx, carrier.Get)
+}
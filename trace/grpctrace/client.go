@@ -0,0 +1,104 @@
+package grpctrace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// UnaryClientInterceptor returns an interceptor that starts a client span for each
+// unary call and injects the trace context into the outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		ctx, span := startSpan(ctx, method, oteltrace.SpanKindClient)
+
+		err := invoker(injectOutgoing(ctx), method, req, reply, cc, opts...)
+		endSpan(span, err, oteltrace.SpanKindClient)
+		recordCall(ctx, oteltrace.SpanKindClient, method, start, err, 1, messageCount(err))
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns an interceptor that starts a client span for each
+// stream. The span ends when the stream is exhausted or fails.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		start := time.Now()
+		ctx, span := startSpan(ctx, method, oteltrace.SpanKindClient)
+
+		stream, err := streamer(injectOutgoing(ctx), desc, cc, method, opts...)
+		if err != nil {
+			endSpan(span, err, oteltrace.SpanKindClient)
+			recordCall(ctx, oteltrace.SpanKindClient, method, start, err, 0, 0)
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: stream, span: span, ctx: ctx, method: method, start: start}, nil
+	}
+}
+
+// clientStream ends the span and records the metrics of the call once the stream terminates.
+type clientStream struct {
+	grpc.ClientStream
+
+	span     oteltrace.Span
+	ctx      context.Context
+	method   string
+	start    time.Time
+	sent     atomic.Int64
+	received atomic.Int64
+	once     sync.Once
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+	if errors.Is(err, io.EOF) {
+		s.end(nil)
+	} else if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *clientStream) end(err error) {
+	s.once.Do(func() {
+		endSpan(s.span, err, oteltrace.SpanKindClient)
+		recordCall(s.ctx, oteltrace.SpanKindClient, s.method, s.start, err, s.sent.Load(), s.received.Load())
+	})
+}
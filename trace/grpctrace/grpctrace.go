@@ -0,0 +1,88 @@
+// Package grpctrace provides gRPC client and server interceptors that create spans
+// through the global tracer, propagate trace context in request metadata and record call
+// durations and message counts through the metric package.
+package grpctrace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// injectOutgoing injects the trace context from ctx into the outgoing gRPC metadata.
+func injectOutgoing(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	InjectMetadata(ctx, md)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// extractIncoming extracts the remote trace context from the incoming gRPC metadata
+// and honors the debug header configured in the trace package.
+func extractIncoming(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return ExtractMetadata(ctx, md)
+}
+
+// startSpan starts a span of the given kind for a gRPC method.
+func startSpan(
+	ctx context.Context,
+	fullMethod string,
+	kind oteltrace.SpanKind,
+) (context.Context, oteltrace.Span) {
+	name, attrs := spanInfo(fullMethod)
+	//nolint:spancheck // span is returned to caller who is responsible for ending it
+	return trace.Span(
+		ctx,
+		name,
+		oteltrace.WithSpanKind(kind),
+		oteltrace.WithAttributes(attrs...),
+	)
+}
+
+// spanInfo returns the span name and RPC attributes for a full method name
+// in the form "/package.Service/Method".
+func spanInfo(fullMethod string) (string, []attribute.KeyValue) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+
+	service, method, found := strings.Cut(name, "/")
+	if !found {
+		return name, attrs
+	}
+	if service != "" {
+		attrs = append(attrs, semconv.RPCService(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethod(method))
+	}
+	return name, attrs
+}
+
+// endSpan records the gRPC status of err on the span and ends it.
+// Server spans only report server-side failures as errors, following the RPC conventions.
+func endSpan(span oteltrace.Span, err error, kind oteltrace.SpanKind) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+
+	if err != nil && isErrorCode(st, kind) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, st.Message())
+	}
+
+	span.End()
+}
@@ -0,0 +1,123 @@
+package grpctrace
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	serverDurationName        = "rpc.server.duration"
+	serverRequestsPerRPCName  = "rpc.server.requests_per_rpc"
+	serverResponsesPerRPCName = "rpc.server.responses_per_rpc"
+	clientDurationName        = "rpc.client.duration"
+	clientRequestsPerRPCName  = "rpc.client.requests_per_rpc"
+	clientResponsesPerRPCName = "rpc.client.responses_per_rpc"
+)
+
+var (
+	serverInstruments = &instruments{
+		durationName:  serverDurationName,
+		requestsName:  serverRequestsPerRPCName,
+		responsesName: serverResponsesPerRPCName,
+		side:          "server",
+	}
+	clientInstruments = &instruments{
+		durationName:  clientDurationName,
+		requestsName:  clientRequestsPerRPCName,
+		responsesName: clientResponsesPerRPCName,
+		side:          "client",
+	}
+)
+
+// rpcMetrics are the RPC instruments of one side and one meter.
+type rpcMetrics struct {
+	duration  otelmetric.Float64Histogram
+	requests  otelmetric.Int64Histogram
+	responses otelmetric.Int64Histogram
+}
+
+// instruments holds the RPC instruments of one side for the current global meter.
+type instruments struct {
+	durationName, requestsName, responsesName, side string
+
+	mu      sync.Mutex
+	meter   otelmetric.Meter
+	current *rpcMetrics
+}
+
+// get returns the instruments of the current global meter, recreating them when the
+// metric package was (re)initialized. Instruments that fail to be created are no-ops.
+func (i *instruments) get() *rpcMetrics {
+	meter := metric.Meter()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if meter == i.meter && i.current != nil {
+		return i.current
+	}
+
+	current := &rpcMetrics{}
+	var err error
+	if current.duration, err = meter.Float64Histogram(
+		i.durationName,
+		otelmetric.WithDescription("Duration of gRPC "+i.side+" calls"),
+		otelmetric.WithUnit("ms"),
+	); err != nil {
+		current.duration = noop.Float64Histogram{}
+	}
+	if current.requests, err = meter.Int64Histogram(
+		i.requestsName,
+		otelmetric.WithDescription("Number of request messages per gRPC "+i.side+" call"),
+		otelmetric.WithUnit("{count}"),
+	); err != nil {
+		current.requests = noop.Int64Histogram{}
+	}
+	if current.responses, err = meter.Int64Histogram(
+		i.responsesName,
+		otelmetric.WithDescription("Number of response messages per gRPC "+i.side+" call"),
+		otelmetric.WithUnit("{count}"),
+	); err != nil {
+		current.responses = noop.Int64Histogram{}
+	}
+
+	i.meter = meter
+	i.current = current
+	return current
+}
+
+// recordCall records the duration and message counts of a call of fullMethod, attributed
+// by service, method and status code.
+func recordCall(
+	ctx context.Context,
+	kind oteltrace.SpanKind,
+	fullMethod string,
+	start time.Time,
+	err error,
+	requests, responses int64,
+) {
+	instruments := serverInstruments
+	if kind == oteltrace.SpanKindClient {
+		instruments = clientInstruments
+	}
+	current := instruments.get()
+
+	_, attrs := spanInfo(fullMethod)
+	st, _ := status.FromError(err)
+	attrs = append(attrs, semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	set := otelmetric.WithAttributeSet(attribute.NewSet(attrs...))
+
+	elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+	current.duration.Record(ctx, elapsed, set)
+	current.requests.Record(ctx, requests, set)
+	current.responses.Record(ctx, responses, set)
+}
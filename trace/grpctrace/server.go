@@ -0,0 +1,90 @@
+package grpctrace
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns an interceptor that extracts the remote trace
+// context from incoming metadata and starts a server span for each unary call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		ctx, span := startSpan(extractIncoming(ctx), info.FullMethod, oteltrace.SpanKindServer)
+
+		resp, err := handler(ctx, req)
+		endSpan(span, err, oteltrace.SpanKindServer)
+		recordCall(ctx, oteltrace.SpanKindServer, info.FullMethod, start, err, 1, messageCount(err))
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that extracts the remote trace
+// context from incoming metadata and starts a server span for each stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		ctx, span := startSpan(extractIncoming(ss.Context()), info.FullMethod, oteltrace.SpanKindServer)
+
+		stream := &serverStream{ServerStream: ss, ctx: ctx}
+		err := handler(srv, stream)
+		endSpan(span, err, oteltrace.SpanKindServer)
+		recordCall(ctx, oteltrace.SpanKindServer, info.FullMethod, start, err,
+			stream.received.Load(), stream.sent.Load())
+
+		return err
+	}
+}
+
+// serverStream overrides the stream context so handlers see the server span, and counts
+// the messages of the stream.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+	return err
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+	return err
+}
+
+// messageCount returns the number of response messages of a unary call.
+func messageCount(err error) int64 {
+	if err != nil {
+		return 0
+	}
+	return 1
+}
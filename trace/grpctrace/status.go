@@ -0,0 +1,32 @@
+package grpctrace
+
+import (
+	oteltrace "go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isErrorCode reports whether the status should mark the span as failed.
+// Clients treat every non-OK code as an error; servers only treat codes that
+// indicate a server-side problem as errors.
+func isErrorCode(st *status.Status, kind oteltrace.SpanKind) bool {
+	if st.Code() == grpccodes.OK {
+		return false
+	}
+	if kind != oteltrace.SpanKindServer {
+		return true
+	}
+
+	//nolint:exhaustive // only server-side failure codes are listed
+	switch st.Code() {
+	case grpccodes.Unknown,
+		grpccodes.DeadlineExceeded,
+		grpccodes.Unimplemented,
+		grpccodes.Internal,
+		grpccodes.Unavailable,
+		grpccodes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,110 @@
+package trace
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanStartHook is called synchronously when a recording span starts.
+type SpanStartHook func(ctx context.Context, span sdktrace.ReadWriteSpan)
+
+// SpanEndHook is called synchronously when a recording span ends.
+type SpanEndHook func(span sdktrace.ReadOnlySpan)
+
+var globalHooks = &spanHooks{}
+
+// OnSpanStart registers a hook run for every recording span started by the global tracer
+// provider, e.g. to add attributes. Hooks can be registered before or after Initialize and
+// must be fast, they run on the caller's goroutine. The returned function removes the hook.
+func OnSpanStart(hook SpanStartHook) (unregister func()) {
+	return globalHooks.addStart(hook)
+}
+
+// OnSpanEnd registers a hook run for every recording span that ends, e.g. for auditing or
+// metrics. The same rules as OnSpanStart apply. The returned function removes the hook.
+func OnSpanEnd(hook SpanEndHook) (unregister func()) {
+	return globalHooks.addEnd(hook)
+}
+
+// spanHooks is a span processor running the registered hooks in registration order.
+// The hook slices are copied on write so hooks run without holding the lock.
+type spanHooks struct {
+	mu     sync.RWMutex
+	nextID int
+	start  []registeredHook[SpanStartHook]
+	end    []registeredHook[SpanEndHook]
+}
+
+type registeredHook[T any] struct {
+	id   int
+	hook T
+}
+
+var _ sdktrace.SpanProcessor = (*spanHooks)(nil)
+
+func (h *spanHooks) addStart(hook SpanStartHook) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	h.start = append(slices.Clip(h.start), registeredHook[SpanStartHook]{id: id, hook: hook})
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.start = withoutHook(h.start, id)
+	}
+}
+
+func (h *spanHooks) addEnd(hook SpanEndHook) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	h.end = append(slices.Clip(h.end), registeredHook[SpanEndHook]{id: id, hook: hook})
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.end = withoutHook(h.end, id)
+	}
+}
+
+func withoutHook[T any](hooks []registeredHook[T], id int) []registeredHook[T] {
+	return slices.DeleteFunc(slices.Clone(hooks), func(h registeredHook[T]) bool {
+		return h.id == id
+	})
+}
+
+func (h *spanHooks) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	h.mu.RLock()
+	hooks := h.start
+	h.mu.RUnlock()
+
+	for _, registered := range hooks {
+		registered.hook(ctx, s)
+	}
+}
+
+func (h *spanHooks) OnEnd(s sdktrace.ReadOnlySpan) {
+	h.mu.RLock()
+	hooks := h.end
+	h.mu.RUnlock()
+
+	for _, registered := range hooks {
+		registered.hook(s)
+	}
+}
+
+func (h *spanHooks) Shutdown(context.Context) error {
+	return nil
+}
+
+func (h *spanHooks) ForceFlush(context.Context) error {
+	return nil
+}
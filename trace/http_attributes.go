@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// HTTPAttributeOption configures SetHTTPAttributes.
+type HTTPAttributeOption func(*httpAttributeConfig)
+
+type httpAttributeConfig struct {
+	redactQueryParameters []string
+	recordUserAgent       bool
+}
+
+// WithRedactedQueryParameters lists the query parameters whose values are recorded as
+// REDACTED, instead of the httptrace.DefaultRedactedQueryParameters. Passing none
+// disables redaction.
+func WithRedactedQueryParameters(names ...string) HTTPAttributeOption {
+	return func(c *httpAttributeConfig) {
+		c.redactQueryParameters = append([]string{}, names...)
+	}
+}
+
+// WithUserAgent records user_agent.original, off by default for privacy.
+func WithUserAgent() HTTPAttributeOption {
+	return func(c *httpAttributeConfig) {
+		c.recordUserAgent = true
+	}
+}
+
+// SetHTTPAttributes sets the stable HTTP semantic convention attributes of req and the
+// response status code on span, for handlers and clients not using httptrace. Server
+// requests (received by an http.Server) get url.path and url.scheme, client requests
+// url.full. A statusCode of 0 means no response was received. Error statuses (5xx for
+// servers, 4xx and 5xx for clients) also set error.type and the span status. Methods
+// outside RFC 9110 and RFC 5789 are recorded as _OTHER with the original method. Query
+// parameters and user agent follow the httptrace rules: sensitive values are redacted
+// and user_agent.original is recorded only WithUserAgent.
+func SetHTTPAttributes(span oteltrace.Span, req *http.Request, statusCode int, opts ...HTTPAttributeOption) {
+	config := httpAttributeConfig{redactQueryParameters: httpconv.DefaultRedactedQueryParameters}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	u := httpconv.NewURLRedactor(config.redactQueryParameters, nil).Redact(req.URL)
+	server := req.RequestURI != ""
+
+	attrs := httpconv.MethodAttributes(req.Method)
+	host := req.URL.Host
+	if server {
+		scheme := "http"
+		if req.TLS != nil {
+			scheme = "https"
+		}
+		attrs = append(attrs, semconv.URLPath(u.Path), semconv.URLScheme(scheme))
+		if u.RawQuery != "" {
+			attrs = append(attrs, semconv.URLQuery(u.RawQuery))
+		}
+		host = req.Host
+	} else {
+		attrs = append(attrs, semconv.URLFull(u.String()))
+	}
+
+	if address, port := httpconv.SplitHostPort(host); address != "" {
+		attrs = append(attrs, semconv.ServerAddress(address))
+		if port > 0 {
+			attrs = append(attrs, semconv.ServerPort(port))
+		}
+	}
+	if req.ProtoMajor > 0 {
+		attrs = append(attrs, semconv.NetworkProtocolVersion(strconv.Itoa(req.ProtoMajor)+"."+strconv.Itoa(req.ProtoMinor)))
+	}
+	if config.recordUserAgent {
+		attrs = append(attrs, httpconv.UserAgentAttributes(req)...)
+	}
+
+	if statusCode > 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(statusCode))
+		if statusCode >= http.StatusInternalServerError || (!server && statusCode >= http.StatusBadRequest) {
+			attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(statusCode)))
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+	}
+
+	span.SetAttributes(attrs...)
+}
@@ -0,0 +1,138 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	sdktracetest "go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+func TestSetHTTPAttributes(t *testing.T) {
+	client := func(method, url string) *http.Request {
+		r, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		statusCode int
+		opts       []HTTPAttributeOption
+		want       map[attribute.Key]string
+		absent     []attribute.Key
+		wantStatus codes.Code
+	}{
+		{
+			name:       "server request",
+			req:        httptest.NewRequest("GET", "http://example.com:8080/orders?id=1&token=secret", nil),
+			statusCode: http.StatusOK,
+			want: map[attribute.Key]string{
+				semconv.HTTPRequestMethodKey:      "GET",
+				semconv.URLPathKey:                "/orders",
+				semconv.URLSchemeKey:              "http",
+				semconv.URLQueryKey:               "id=1&token=REDACTED",
+				semconv.ServerAddressKey:          "example.com",
+				semconv.ServerPortKey:             "8080",
+				semconv.NetworkProtocolVersionKey: "1.1",
+				semconv.HTTPResponseStatusCodeKey: "200",
+			},
+			absent: []attribute.Key{semconv.URLFullKey, semconv.ErrorTypeKey},
+		},
+		{
+			name:       "server error",
+			req:        httptest.NewRequest("POST", "/orders", nil),
+			statusCode: http.StatusBadGateway,
+			want: map[attribute.Key]string{
+				semconv.ErrorTypeKey: "502",
+			},
+			wantStatus: codes.Error,
+		},
+		{
+			name:       "server client error is not an error",
+			req:        httptest.NewRequest("GET", "/orders", nil),
+			statusCode: http.StatusNotFound,
+			absent:     []attribute.Key{semconv.ErrorTypeKey},
+		},
+		{
+			name:       "client request",
+			req:        client("GET", "https://api.example.com/v1?sig=abc"),
+			statusCode: http.StatusNotFound,
+			want: map[attribute.Key]string{
+				semconv.URLFullKey:       "https://api.example.com/v1?sig=REDACTED",
+				semconv.ServerAddressKey: "api.example.com",
+				semconv.ErrorTypeKey:     "404",
+			},
+			absent:     []attribute.Key{semconv.URLPathKey},
+			wantStatus: codes.Error,
+		},
+		{
+			name: "unknown method",
+			req:  client("PURGE", "https://cache.example.com/a"),
+			want: map[attribute.Key]string{
+				semconv.HTTPRequestMethodKey:         "_OTHER",
+				semconv.HTTPRequestMethodOriginalKey: "PURGE",
+			},
+			absent: []attribute.Key{semconv.HTTPResponseStatusCodeKey},
+		},
+		{
+			name: "configured redaction",
+			req:  client("GET", "https://api.example.com/v1?sig=abc&user=bob"),
+			opts: []HTTPAttributeOption{WithRedactedQueryParameters("user")},
+			want: map[attribute.Key]string{
+				semconv.URLFullKey: "https://api.example.com/v1?sig=abc&user=REDACTED",
+			},
+		},
+		{
+			name: "user agent",
+			req: func() *http.Request {
+				r := client("GET", "https://api.example.com")
+				r.Header.Set("User-Agent", "orders/1.0")
+				return r
+			}(),
+			opts: []HTTPAttributeOption{WithUserAgent()},
+			want: map[attribute.Key]string{
+				semconv.UserAgentOriginalKey: "orders/1.0",
+			},
+		},
+		{
+			name:   "user agent off by default",
+			req:    client("GET", "https://api.example.com"),
+			absent: []attribute.Key{semconv.UserAgentOriginalKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := sdktracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			_, span := tp.Tracer("test").Start(context.Background(), "request")
+			SetHTTPAttributes(span, tt.req, tt.statusCode, tt.opts...)
+			span.End()
+
+			ended := recorder.Ended()[0]
+			attrs := attribute.NewSet(ended.Attributes()...)
+			for key, want := range tt.want {
+				if got, ok := attrs.Value(key); !ok || got.Emit() != want {
+					t.Errorf("%s = %q, want %q", key, got.Emit(), want)
+				}
+			}
+			for _, key := range tt.absent {
+				if got, ok := attrs.Value(key); ok {
+					t.Errorf("%s = %q, want none", key, got.Emit())
+				}
+			}
+			if got := ended.Status().Code; got != tt.wantStatus {
+				t.Errorf("status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	defaultHTTPJSONTimeout = 10 * time.Second
+	httpTracesPath         = "/v1/traces"
+	maxErrorBodySize       = 1024
+)
+
+// httpJSONClient is an otlptrace.Client that sends spans using the OTLP/HTTP JSON encoding.
+type httpJSONClient struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+var _ otlptrace.Client = (*httpJSONClient)(nil)
+
+// newHTTPJSONExporter creates a new OTLP HTTP exporter using JSON encoding
+func newHTTPJSONExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExporter, error) {
+	scheme := "https://"
+	if config.Insecure {
+		scheme = "http://"
+	}
+
+	path := httpTracesPath
+	if config.URLPath != "" {
+		path = config.URLPath
+	}
+
+	client := &httpJSONClient{
+		url:     scheme + config.TraceURL + path,
+		headers: config.Headers,
+		client:  &http.Client{Timeout: defaultHTTPJSONTimeout},
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateHTTPExporter, err)
+	}
+
+	return exporter, nil
+}
+
+// Start is a no-op, connections are established per request.
+func (c *httpJSONClient) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop closes idle connections.
+func (c *httpJSONClient) Stop(_ context.Context) error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// UploadTraces encodes the spans as OTLP JSON and posts them to the traces endpoint.
+func (c *httpJSONClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	payload, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMarshalSpans, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSendSpans, err)
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSendSpans, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("%w: status %d: %s", ErrSendSpans, resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
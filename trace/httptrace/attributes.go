@@ -0,0 +1,141 @@
+package httptrace
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+	"go.opentelemetry.io/otel/attribute"
+	semconvlegacy "go.opentelemetry.io/otel/semconv/v1.20.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+// serverRequestAttributes returns the semantic attributes of an incoming request
+// for the given semantic convention stability mode.
+func serverRequestAttributes(r *http.Request, mode trace.SemconvStability, redactor httpconv.URLRedactor) []attribute.KeyValue {
+	u := redactor.Redact(r.URL)
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host, port := httpconv.SplitHostPort(r.Host)
+
+	attrs := methodAttributes(r.Method, mode)
+	if mode.EmitStable() {
+		attrs = append(attrs,
+			semconv.URLPath(u.Path),
+			semconv.URLScheme(scheme),
+		)
+		if u.RawQuery != "" {
+			attrs = append(attrs, semconv.URLQuery(u.RawQuery))
+		}
+		attrs = append(attrs, hostAttributes(semconv.ServerAddressKey, semconv.ServerPortKey, host, port)...)
+	}
+	if mode.EmitLegacy() {
+		attrs = append(attrs,
+			semconvlegacy.HTTPTargetKey.String(u.RequestURI()),
+			semconvlegacy.HTTPSchemeKey.String(scheme),
+		)
+		attrs = append(attrs, hostAttributes(semconvlegacy.NetHostNameKey, semconvlegacy.NetHostPortKey, host, port)...)
+	}
+	return attrs
+}
+
+// clientRequestAttributes returns the semantic attributes of an outgoing request
+// for the given semantic convention stability mode.
+func clientRequestAttributes(r *http.Request, mode trace.SemconvStability, redactor httpconv.URLRedactor) []attribute.KeyValue {
+	u := redactor.Redact(r.URL)
+	host, port := httpconv.SplitHostPort(r.URL.Host)
+
+	attrs := methodAttributes(r.Method, mode)
+	if mode.EmitStable() {
+		attrs = append(attrs, semconv.URLFull(u.String()))
+		attrs = append(attrs, hostAttributes(semconv.ServerAddressKey, semconv.ServerPortKey, host, port)...)
+	}
+	if mode.EmitLegacy() {
+		attrs = append(attrs, semconvlegacy.HTTPURLKey.String(u.String()))
+		attrs = append(attrs, hostAttributes(semconvlegacy.NetPeerNameKey, semconvlegacy.NetPeerPortKey, host, port)...)
+	}
+	return attrs
+}
+
+// methodAttributes returns the request method attributes, "_OTHER" with the method as
+// http.request.method_original for methods clients may make up.
+func methodAttributes(method string, mode trace.SemconvStability) []attribute.KeyValue {
+	known := httpconv.KnownMethod(method)
+
+	var attrs []attribute.KeyValue
+	if mode.EmitStable() {
+		attrs = append(attrs, semconv.HTTPRequestMethodKey.String(known))
+	}
+	if mode.EmitLegacy() {
+		attrs = append(attrs, semconvlegacy.HTTPMethodKey.String(known))
+	}
+	if known != method {
+		attrs = append(attrs, semconv.HTTPRequestMethodOriginal(method))
+	}
+	return attrs
+}
+
+// clientAddressAttributes returns the client address of an incoming request. With
+// trustedProxies > 0 it is taken from X-Forwarded-For, skipping the addresses appended
+// by that many proxies, otherwise from the connection's remote address.
+func clientAddressAttributes(r *http.Request, mode trace.SemconvStability, trustedProxies int) []attribute.KeyValue {
+	address := clientAddress(r, trustedProxies)
+	if address == "" {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if mode.EmitStable() {
+		attrs = append(attrs, semconv.ClientAddress(address))
+	}
+	if mode.EmitLegacy() {
+		attrs = append(attrs, semconvlegacy.HTTPClientIP(address))
+	}
+	return attrs
+}
+
+func clientAddress(r *http.Request, trustedProxies int) string {
+	if trustedProxies > 0 {
+		var forwarded []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, address := range strings.Split(header, ",") {
+				if address = strings.TrimSpace(address); address != "" {
+					forwarded = append(forwarded, address)
+				}
+			}
+		}
+		if len(forwarded) > 0 {
+			return forwarded[max(len(forwarded)-trustedProxies, 0)]
+		}
+	}
+
+	host, _ := httpconv.SplitHostPort(r.RemoteAddr)
+	return host
+}
+
+// statusCodeAttributes returns the response status code attributes.
+func statusCodeAttributes(status int, mode trace.SemconvStability) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if mode.EmitStable() {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+	}
+	if mode.EmitLegacy() {
+		attrs = append(attrs, semconvlegacy.HTTPStatusCodeKey.Int(status))
+	}
+	return attrs
+}
+
+// hostAttributes returns the address and port attributes using the given keys.
+func hostAttributes(addressKey, portKey attribute.Key, host string, port int) []attribute.KeyValue {
+	if host == "" {
+		return nil
+	}
+	attrs := []attribute.KeyValue{addressKey.String(host)}
+	if port > 0 {
+		attrs = append(attrs, portKey.Int(port))
+	}
+	return attrs
+}
@@ -0,0 +1,193 @@
+package httptrace
+
+import (
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	semconvlegacy "go.opentelemetry.io/otel/semconv/v1.20.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+func TestServerRequestAttributesRedactsQuery(t *testing.T) {
+	tests := []struct {
+		name            string
+		queryParameters []string
+		target          string
+		wantQuery       string
+	}{
+		{
+			name:      "default parameters",
+			target:    "/login?user=bob&token=secret&Password=hunter2",
+			wantQuery: "user=bob&token=REDACTED&Password=REDACTED",
+		},
+		{
+			name:            "configured parameters",
+			queryParameters: []string{"user"},
+			target:          "/login?user=bob&token=secret",
+			wantQuery:       "user=REDACTED&token=secret",
+		},
+		{
+			name:            "redaction disabled",
+			queryParameters: []string{},
+			target:          "/login?token=secret",
+			wantQuery:       "token=secret",
+		},
+		{
+			name:      "no query",
+			target:    "/login",
+			wantQuery: "",
+		},
+	}
+
+	mode, err := trace.NewSemconvStability(trace.SemconvStabilityDuplicate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			attrs := attribute.NewSet(serverRequestAttributes(r, mode, newURLRedactor(tt.queryParameters, nil))...)
+
+			query, ok := attrs.Value(semconv.URLQueryKey)
+			if tt.wantQuery == "" {
+				if ok {
+					t.Errorf("url.query = %q, want none", query.AsString())
+				}
+				return
+			}
+			if query.AsString() != tt.wantQuery {
+				t.Errorf("url.query = %q, want %q", query.AsString(), tt.wantQuery)
+			}
+			if target, _ := attrs.Value(semconvlegacy.HTTPTargetKey); target.AsString() != "/login?"+tt.wantQuery {
+				t.Errorf("http.target = %q, want %q", target.AsString(), "/login?"+tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestMethodAttributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		mode   string
+		want   []attribute.KeyValue
+	}{
+		{
+			name:   "stable",
+			method: "GET",
+			mode:   trace.SemconvStabilityStable,
+			want:   []attribute.KeyValue{semconv.HTTPRequestMethodKey.String("GET")},
+		},
+		{
+			name:   "legacy",
+			method: "GET",
+			mode:   trace.SemconvStabilityLegacy,
+			want:   []attribute.KeyValue{semconvlegacy.HTTPMethodKey.String("GET")},
+		},
+		{
+			name:   "duplicate",
+			method: "POST",
+			mode:   trace.SemconvStabilityDuplicate,
+			want: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String("POST"),
+				semconvlegacy.HTTPMethodKey.String("POST"),
+			},
+		},
+		{
+			name:   "unknown method",
+			method: "PURGE",
+			mode:   trace.SemconvStabilityDuplicate,
+			want: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String("_OTHER"),
+				semconvlegacy.HTTPMethodKey.String("_OTHER"),
+				semconv.HTTPRequestMethodOriginal("PURGE"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := trace.NewSemconvStability(tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := methodAttributes(tt.method, mode); !slices.Equal(got, tt.want) {
+				t.Errorf("methodAttributes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRequestAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		url  string
+		want map[attribute.Key]attribute.Value
+	}{
+		{
+			name: "stable",
+			mode: trace.SemconvStabilityStable,
+			url:  "https://api.example.com:8443/v1/orders?token=secret",
+			want: map[attribute.Key]attribute.Value{
+				semconv.HTTPRequestMethodKey: attribute.StringValue("GET"),
+				semconv.URLFullKey:           attribute.StringValue("https://api.example.com:8443/v1/orders?token=REDACTED"),
+				semconv.ServerAddressKey:     attribute.StringValue("api.example.com"),
+				semconv.ServerPortKey:        attribute.IntValue(8443),
+			},
+		},
+		{
+			name: "legacy",
+			mode: trace.SemconvStabilityLegacy,
+			url:  "http://api.example.com/v1/orders",
+			want: map[attribute.Key]attribute.Value{
+				semconvlegacy.HTTPMethodKey:  attribute.StringValue("GET"),
+				semconvlegacy.HTTPURLKey:     attribute.StringValue("http://api.example.com/v1/orders"),
+				semconvlegacy.NetPeerNameKey: attribute.StringValue("api.example.com"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := trace.NewSemconvStability(tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest("GET", tt.url, nil)
+			got := clientRequestAttributes(r, mode, newURLRedactor(nil, nil))
+			if len(got) != len(tt.want) {
+				t.Fatalf("clientRequestAttributes = %v, want %v", got, tt.want)
+			}
+			for _, kv := range got {
+				if want, ok := tt.want[kv.Key]; !ok || kv.Value != want {
+					t.Errorf("%s = %v, want %v", kv.Key, kv.Value.Emit(), want.Emit())
+				}
+			}
+		})
+	}
+}
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		method string
+		route  string
+		want   string
+	}{
+		{method: "GET", route: "/users/{id}", want: "GET /users/{id}"},
+		{method: "POST", want: "POST"},
+		{method: "PURGE", route: "/cache", want: "HTTP /cache"},
+		{method: "get", want: "HTTP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := spanName(tt.method, tt.route); got != tt.want {
+				t.Errorf("spanName(%q, %q) = %q, want %q", tt.method, tt.route, got, tt.want)
+			}
+		})
+	}
+}
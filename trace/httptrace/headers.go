@@ -0,0 +1,44 @@
+package httptrace
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// sensitiveHeaders are always recorded as REDACTED, even when allow-listed.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// headerAttributes returns http.request.header.<name> or http.response.header.<name>
+// attributes for the allow-listed headers present in h. Sensitive values are redacted.
+func headerAttributes(prefix string, h http.Header, allowed []string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for _, name := range allowed {
+		canonical := http.CanonicalHeaderKey(name)
+		values := h.Values(canonical)
+		if len(values) == 0 {
+			continue
+		}
+		if sensitiveHeaders[canonical] {
+			values = []string{httpconv.RedactedValue}
+		}
+		key := attribute.Key(prefix + strings.ToLower(canonical))
+		attrs = append(attrs, key.StringSlice(values))
+	}
+	return attrs
+}
+
+func requestHeaderAttributes(h http.Header, allowed []string) []attribute.KeyValue {
+	return headerAttributes("http.request.header.", h, allowed)
+}
+
+func responseHeaderAttributes(h http.Header, allowed []string) []attribute.KeyValue {
+	return headerAttributes("http.response.header.", h, allowed)
+}
@@ -0,0 +1,148 @@
+package httptrace
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+const (
+	requestDurationName  = "http.server.request.duration"
+	requestBodySizeName  = "http.server.request.body.size"
+	responseBodySizeName = "http.server.response.body.size"
+	activeRequestsName   = "http.server.active_requests"
+)
+
+// requestDurationBuckets are the bucket boundaries advised by the HTTP semantic conventions.
+var requestDurationBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10,
+}
+
+var serverInstruments = &instruments{}
+
+// serverMetrics are the HTTP server instruments of one meter.
+type serverMetrics struct {
+	duration     otelmetric.Float64Histogram
+	requestSize  otelmetric.Int64Histogram
+	responseSize otelmetric.Int64Histogram
+	active       otelmetric.Int64UpDownCounter
+}
+
+// instruments holds the HTTP server instruments of the current global meter.
+type instruments struct {
+	mu      sync.Mutex
+	meter   otelmetric.Meter
+	current *serverMetrics
+}
+
+// get returns the instruments of the current global meter, recreating them when the
+// metric package was (re)initialized. Instruments that fail to be created are no-ops.
+func (i *instruments) get() *serverMetrics {
+	meter := metric.Meter()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if meter == i.meter && i.current != nil {
+		return i.current
+	}
+
+	current := &serverMetrics{}
+	var err error
+	if current.duration, err = meter.Float64Histogram(
+		requestDurationName,
+		otelmetric.WithDescription("Duration of HTTP server requests"),
+		otelmetric.WithUnit("s"),
+		otelmetric.WithExplicitBucketBoundaries(requestDurationBuckets...),
+	); err != nil {
+		current.duration = noop.Float64Histogram{}
+	}
+	if current.requestSize, err = meter.Int64Histogram(
+		requestBodySizeName,
+		otelmetric.WithDescription("Size of HTTP server request bodies"),
+		otelmetric.WithUnit("By"),
+	); err != nil {
+		current.requestSize = noop.Int64Histogram{}
+	}
+	if current.responseSize, err = meter.Int64Histogram(
+		responseBodySizeName,
+		otelmetric.WithDescription("Size of HTTP server response bodies"),
+		otelmetric.WithUnit("By"),
+	); err != nil {
+		current.responseSize = noop.Int64Histogram{}
+	}
+	if current.active, err = meter.Int64UpDownCounter(
+		activeRequestsName,
+		otelmetric.WithDescription("Number of active HTTP server requests"),
+		otelmetric.WithUnit("{request}"),
+	); err != nil {
+		current.active = noop.Int64UpDownCounter{}
+	}
+
+	i.meter = meter
+	i.current = current
+	return current
+}
+
+// activeRequestAttributes returns the attributes of http.server.active_requests, known
+// when the request starts.
+func activeRequestAttributes(r *http.Request) attribute.Set {
+	return attribute.NewSet(
+		semconv.HTTPRequestMethodKey.String(httpconv.KnownMethod(r.Method)),
+		semconv.URLScheme(requestScheme(r)),
+	)
+}
+
+// requestMetricAttributes returns the attributes of the request duration and sizes.
+func requestMetricAttributes(r *http.Request, route string, status int) attribute.Set {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(httpconv.KnownMethod(r.Method)),
+		semconv.URLScheme(requestScheme(r)),
+		semconv.HTTPResponseStatusCode(status),
+	}
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	if status >= http.StatusInternalServerError {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(status)))
+	}
+	return attribute.NewSet(attrs...)
+}
+
+// spanName returns the span name of a request, "HTTP" for unknown methods.
+func spanName(method, route string) string {
+	if method = httpconv.KnownMethod(method); method == httpconv.OtherMethod {
+		method = "HTTP"
+	}
+	if route == "" {
+		return method
+	}
+	return method + " " + route
+}
+
+func requestScheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// routeFromPattern returns the route of a http.ServeMux pattern such as
+// "GET example.com/users/{id}", i.e. "/users/{id}", or "" without pattern.
+func routeFromPattern(pattern string) string {
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		pattern = path
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
@@ -0,0 +1,123 @@
+// Package httptrace provides an HTTP server middleware and a client transport that
+// create spans through the global tracer and propagate trace context in headers.
+package httptrace
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// MiddlewareConfig configures the server middleware.
+type MiddlewareConfig struct {
+	// CaptureRequestHeaders and CaptureResponseHeaders list headers recorded as
+	// http.request.header.<name> and http.response.header.<name> span attributes.
+	// Authorization, Proxy-Authorization, Cookie and Set-Cookie values are redacted.
+	CaptureRequestHeaders  []string
+	CaptureResponseHeaders []string
+	// RedactQueryParameters lists query parameters whose values are recorded as REDACTED
+	// in url.query and http.target, DefaultRedactedQueryParameters when nil. Use an empty
+	// slice to disable.
+	RedactQueryParameters []string
+	// RedactPathSegments replaces path segments matching any pattern (e.g. ^[0-9a-f-]{36}$
+	// for reset tokens) by REDACTED in url.path and http.target.
+	RedactPathSegments []*regexp.Regexp
+	// RecordClientAddress records client.address, off by default for privacy.
+	// TrustedProxies is the number of reverse proxies in front of the service; when
+	// greater than 0 the address is read from X-Forwarded-For instead of the connection.
+	RecordClientAddress bool
+	TrustedProxies      int
+	// RecordUserAgent records user_agent.original, off by default for privacy.
+	RecordUserAgent bool
+	// RouteExtractor returns the route template of a request, e.g. "/users/{id}", for
+	// routers other than http.ServeMux. It is called once the handler has served the
+	// request, so routers that resolve the route during dispatch can report it; an empty
+	// result falls back to the http.ServeMux pattern.
+	RouteExtractor func(*http.Request) string
+}
+
+// Middleware wraps the handler with the default middleware configuration.
+func Middleware(next http.Handler) http.Handler {
+	return NewMiddleware(MiddlewareConfig{})(next)
+}
+
+// NewMiddleware returns a middleware that extracts the remote trace context from the
+// request headers and starts a server span for every request. Through the metric package,
+// it also records the http.server.request.duration, http.server.request.body.size and
+// http.server.response.body.size histograms and the http.server.active_requests gauge.
+// Requests routed by http.ServeMux, or whose route is returned by RouteExtractor, get it
+// as http.route, also used in the span name, e.g. "GET /users/{id}". Methods outside
+// RFC 9110 are recorded as _OTHER in metrics and named "HTTP" in span names.
+func NewMiddleware(config MiddlewareConfig) func(http.Handler) http.Handler {
+	redactor := newURLRedactor(config.RedactQueryParameters, config.RedactPathSegments)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx = trace.ContextFromDebugHeader(ctx, r.Header.Get)
+			mode := trace.HTTPSemconvStability()
+
+			attrs := serverRequestAttributes(r, mode, redactor)
+			attrs = append(attrs, requestHeaderAttributes(r.Header, config.CaptureRequestHeaders)...)
+			if config.RecordClientAddress {
+				attrs = append(attrs, clientAddressAttributes(r, mode, config.TrustedProxies)...)
+			}
+			if config.RecordUserAgent {
+				attrs = append(attrs, httpconv.UserAgentAttributes(r)...)
+			}
+
+			ctx, span := trace.Span(
+				ctx,
+				spanName(r.Method, ""),
+				oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+				oteltrace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			instruments := serverInstruments.get()
+			start := time.Now()
+			active := otelmetric.WithAttributeSet(activeRequestAttributes(r))
+			instruments.active.Add(ctx, 1, active)
+			defer instruments.active.Add(ctx, -1, active)
+
+			rw := newResponseWriter(w)
+			req := r.WithContext(ctx)
+			next.ServeHTTP(rw, req)
+
+			var route string
+			if config.RouteExtractor != nil {
+				route = config.RouteExtractor(req)
+			}
+			if route == "" {
+				// http.ServeMux sets the matched pattern on the request it serves
+				route = routeFromPattern(req.Pattern)
+			}
+			if route != "" {
+				span.SetName(spanName(r.Method, route))
+				span.SetAttributes(semconv.HTTPRoute(route))
+			}
+
+			metricAttrs := otelmetric.WithAttributeSet(requestMetricAttributes(r, route, rw.status))
+			instruments.duration.Record(ctx, time.Since(start).Seconds(), metricAttrs)
+			if r.ContentLength >= 0 {
+				instruments.requestSize.Record(ctx, r.ContentLength, metricAttrs)
+			}
+			instruments.responseSize.Record(ctx, rw.written, metricAttrs)
+
+			span.SetAttributes(statusCodeAttributes(rw.status, mode)...)
+			span.SetAttributes(responseHeaderAttributes(rw.Header(), config.CaptureResponseHeaders)...)
+			if rw.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rw.status))
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package httptrace
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+)
+
+// DefaultRedactedQueryParameters are the query parameters whose values are redacted
+// when the configuration does not list any: access_token, api_key, apikey, auth,
+// password, secret, sig, signature, token and the X-Amz-Credential,
+// X-Amz-Security-Token and X-Amz-Signature presigned URL parameters.
+var DefaultRedactedQueryParameters = slices.Clone(httpconv.DefaultRedactedQueryParameters)
+
+func newURLRedactor(queryParameters []string, pathSegments []*regexp.Regexp) httpconv.URLRedactor {
+	if queryParameters == nil {
+		queryParameters = DefaultRedactedQueryParameters
+	}
+	return httpconv.NewURLRedactor(queryParameters, pathSegments)
+}
@@ -0,0 +1,134 @@
+package httptrace
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	sseMessageEvent = "sse.message.sent"
+	// pending bytes of an unterminated message beyond this are not parsed
+	maxSSEPendingSize = 64 << 10
+
+	sseEventTypeKey          = attribute.Key("sse.event.type")
+	sseEventIDKey            = attribute.Key("sse.event.id")
+	sseMessageSizeKey        = attribute.Key("sse.message.size")
+	sseMessagesSentKey       = attribute.Key("sse.messages.sent")
+	sseStreamDurationKey     = attribute.Key("sse.stream.duration_ms")
+	sseClientDisconnectedKey = attribute.Key("sse.client.disconnected")
+)
+
+// SSE wraps a Server-Sent Events handler. It adds an sse.message.sent event with the
+// event type, id and size to the request span for every message the handler writes, and
+// sets the message count, stream duration and whether the client disconnected when the
+// handler returns. Keep-alive comments are not reported. The SDK keeps at most 128 events
+// per span, later messages are only counted.
+//
+// The request span is the one of the httptrace middleware; without it, SSE starts a
+// server span itself.
+func SSE(next http.Handler) http.Handler {
+	sse := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := oteltrace.SpanFromContext(r.Context())
+		sw := &sseWriter{ResponseWriter: w, span: span}
+
+		start := time.Now()
+		next.ServeHTTP(sw, r)
+
+		span.SetAttributes(
+			sseMessagesSentKey.Int64(sw.messages),
+			sseStreamDurationKey.Float64(float64(time.Since(start))/float64(time.Millisecond)),
+			sseClientDisconnectedKey.Bool(r.Context().Err() != nil),
+		)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if oteltrace.SpanContextFromContext(r.Context()).IsValid() {
+			sse.ServeHTTP(w, r)
+			return
+		}
+		Middleware(sse).ServeHTTP(w, r)
+	})
+}
+
+// sseWriter splits the written stream into messages, which end with a blank line.
+type sseWriter struct {
+	http.ResponseWriter
+
+	span     oteltrace.Span
+	pending  []byte
+	messages int64
+}
+
+func (w *sseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.parse(b[:n])
+	return n, err
+}
+
+func (w *sseWriter) parse(b []byte) {
+	if len(w.pending)+len(b) > maxSSEPendingSize {
+		w.pending = w.pending[:0]
+		return
+	}
+	w.pending = append(w.pending, bytes.ReplaceAll(b, []byte("\r"), nil)...)
+
+	for {
+		end := bytes.Index(w.pending, []byte("\n\n"))
+		if end < 0 {
+			return
+		}
+		w.message(w.pending[:end])
+		w.pending = w.pending[:copy(w.pending, w.pending[end+2:])]
+	}
+}
+
+// message reports a message unless it only holds comments.
+func (w *sseWriter) message(message []byte) {
+	eventType := "message"
+	var id string
+	hasData := false
+
+	for line := range bytes.SplitSeq(message, []byte("\n")) {
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "":
+			// comment line, e.g. a keep-alive
+		case "event":
+			eventType = string(value)
+		case "id":
+			id = string(value)
+		default:
+			hasData = true
+		}
+	}
+	if !hasData && id == "" {
+		return
+	}
+
+	w.messages++
+	attrs := []attribute.KeyValue{
+		sseEventTypeKey.String(eventType),
+		sseMessageSizeKey.Int(len(message)),
+	}
+	if id != "" {
+		attrs = append(attrs, sseEventIDKey.String(id))
+	}
+	w.span.AddEvent(sseMessageEvent, oteltrace.WithAttributes(attrs...))
+}
+
+// Flush implements http.Flusher, which SSE handlers need to push messages.
+func (w *sseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (w *sseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
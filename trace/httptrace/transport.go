@@ -0,0 +1,77 @@
+package httptrace
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/internal/httpconv"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// TransportConfig configures the client transport.
+type TransportConfig struct {
+	// CaptureRequestHeaders and CaptureResponseHeaders list headers recorded as span
+	// attributes, with the same redaction as MiddlewareConfig.
+	CaptureRequestHeaders  []string
+	CaptureResponseHeaders []string
+	// RedactQueryParameters and RedactPathSegments redact url.full and http.url
+	// like the MiddlewareConfig fields. URL credentials are always redacted.
+	RedactQueryParameters []string
+	RedactPathSegments    []*regexp.Regexp
+}
+
+// Transport is an http.RoundTripper that starts a client span for every request
+// and injects the trace context into the request headers.
+type Transport struct {
+	base     http.RoundTripper
+	config   TransportConfig
+	redactor httpconv.URLRedactor
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport wraps base, or http.DefaultTransport when base is nil.
+func NewTransport(base http.RoundTripper, config TransportConfig) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:     base,
+		config:   config,
+		redactor: newURLRedactor(config.RedactQueryParameters, config.RedactPathSegments),
+	}
+}
+
+// RoundTrip executes a single HTTP transaction inside a client span.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	mode := trace.HTTPSemconvStability()
+	ctx, span := trace.Span(
+		r.Context(),
+		spanName(r.Method, ""),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(clientRequestAttributes(r, mode, t.redactor)...),
+		oteltrace.WithAttributes(requestHeaderAttributes(r.Header, t.config.CaptureRequestHeaders)...),
+	)
+	defer span.End()
+
+	r = r.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(statusCodeAttributes(resp.StatusCode, mode)...)
+	span.SetAttributes(responseHeaderAttributes(resp.Header, t.config.CaptureResponseHeaders)...)
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,21 @@
+package trace
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// processInstanceID is generated once per process so that re-initializing the tracer
+// (e.g. after Shutdown in tests) keeps reporting the same service.instance.id.
+var processInstanceID = sync.OnceValue(func() string {
+	return uuid.NewString()
+})
+
+// serviceInstanceID returns the configured instance ID or the generated per-process ID.
+func serviceInstanceID(config TracerConfig) string {
+	if config.ServiceInstanceID != "" {
+		return config.ServiceInstanceID
+	}
+	return processInstanceID()
+}
@@ -0,0 +1,158 @@
+// Package httpconv holds the HTTP attribute rules shared by the trace package and the
+// httptrace integration, so that both redact and record requests the same way.
+package httpconv
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+// RedactedValue replaces sensitive values in recorded attributes.
+const RedactedValue = "REDACTED"
+
+// DefaultRedactedQueryParameters are the query parameters whose values are redacted
+// when the configuration does not list any.
+var DefaultRedactedQueryParameters = []string{
+	"access_token",
+	"api_key",
+	"apikey",
+	"auth",
+	"password",
+	"secret",
+	"sig",
+	"signature",
+	"token",
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+	"X-Amz-Signature",
+}
+
+// OtherMethod is recorded instead of HTTP methods not in knownMethods.
+const OtherMethod = "_OTHER"
+
+// knownMethods are the HTTP methods of RFC 9110 and RFC 5789 recorded as is.
+var knownMethods = map[string]bool{
+	http.MethodConnect: true,
+	http.MethodDelete:  true,
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPatch:   true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodTrace:   true,
+}
+
+// KnownMethod returns method, or OtherMethod for methods clients may make up, so that
+// they cannot create unbounded metric series or span names.
+func KnownMethod(method string) string {
+	if knownMethods[method] {
+		return method
+	}
+	return OtherMethod
+}
+
+// MethodAttributes returns http.request.method, with the method as
+// http.request.method_original when it is not a known method.
+func MethodAttributes(method string) []attribute.KeyValue {
+	known := KnownMethod(method)
+	if known == method {
+		return []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(method)}
+	}
+	return []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(known), semconv.HTTPRequestMethodOriginal(method)}
+}
+
+// URLRedactor removes sensitive data from URLs before they are recorded as attributes.
+type URLRedactor struct {
+	queryParameters map[string]bool // lower-cased names
+	pathSegments    []*regexp.Regexp
+}
+
+// NewURLRedactor returns a redactor replacing the values of the query parameters named
+// queryParameters, case-insensitively, and the path segments matching pathSegments.
+func NewURLRedactor(queryParameters []string, pathSegments []*regexp.Regexp) URLRedactor {
+	names := make(map[string]bool, len(queryParameters))
+	for _, name := range queryParameters {
+		names[strings.ToLower(name)] = true
+	}
+	return URLRedactor{queryParameters: names, pathSegments: pathSegments}
+}
+
+// Redact returns a copy of u with credentials, sensitive query parameter values and
+// matching path segments replaced by REDACTED.
+func (r URLRedactor) Redact(u *url.URL) *url.URL {
+	redacted := *u
+	if u.User != nil {
+		redacted.User = url.UserPassword(RedactedValue, RedactedValue)
+	}
+	redacted.RawQuery = r.redactQuery(u.RawQuery)
+	if len(r.pathSegments) > 0 {
+		redacted.Path = r.redactPath(u.Path)
+		redacted.RawPath = ""
+	}
+	return &redacted
+}
+
+// redactQuery replaces the values of sensitive parameters, keeping the parameter order.
+func (r URLRedactor) redactQuery(rawQuery string) string {
+	if rawQuery == "" || len(r.queryParameters) == 0 {
+		return rawQuery
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, hasValue := strings.Cut(param, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if hasValue && r.queryParameters[strings.ToLower(name)] {
+			params[i] = key + "=" + RedactedValue
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// redactPath replaces every path segment matching one of the patterns.
+func (r URLRedactor) redactPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		for _, pattern := range r.pathSegments {
+			if segment != "" && pattern.MatchString(segment) {
+				segments[i] = RedactedValue
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// UserAgentAttributes returns the user agent of a request. Callers record it only when
+// configured to, as it can identify users.
+func UserAgentAttributes(r *http.Request) []attribute.KeyValue {
+	userAgent := r.UserAgent()
+	if userAgent == "" {
+		return nil
+	}
+	return []attribute.KeyValue{semconv.UserAgentOriginal(userAgent)}
+}
+
+// SplitHostPort splits a host[:port] value. The port is 0 when absent or invalid.
+func SplitHostPort(hostport string) (string, int) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport, 0
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return host, 0
+	}
+	return host, port
+}
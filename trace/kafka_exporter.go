@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultKafkaWriteBatchTimeout = 10 * time.Millisecond
+
+// kafkaClient is an otlptrace.Client that publishes each exported batch as an
+// OTLP protobuf ExportTraceServiceRequest message to a Kafka topic.
+type kafkaClient struct {
+	writer *kafka.Writer
+}
+
+var _ otlptrace.Client = (*kafkaClient)(nil)
+
+// newKafkaExporter creates a new OTLP exporter that publishes spans to Kafka
+func newKafkaExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExporter, error) {
+	transport := &kafka.Transport{}
+	if !config.Insecure {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := &kafkaClient{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(config.KafkaBrokers...),
+			Topic:        config.KafkaTopic,
+			Balancer:     &kafka.LeastBytes{},
+			BatchTimeout: defaultKafkaWriteBatchTimeout,
+			RequiredAcks: kafka.RequireOne,
+			Transport:    transport,
+		},
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateKafkaExporter, err)
+	}
+
+	return exporter, nil
+}
+
+// Start is a no-op, the writer connects lazily on the first publish.
+func (c *kafkaClient) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop flushes pending messages and closes the writer.
+func (c *kafkaClient) Stop(_ context.Context) error {
+	return c.writer.Close()
+}
+
+// UploadTraces serializes the spans as OTLP protobuf and publishes them to the topic.
+func (c *kafkaClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	payload, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMarshalSpans, err)
+	}
+
+	if err := c.writer.WriteMessages(ctx, kafka.Message{Value: payload}); err != nil {
+		return fmt.Errorf("%w: %w", ErrPublishSpans, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Package kafkatrace propagates trace context in the headers of segmentio/kafka-go
+// messages, for use with trace.InjectCarrier and trace.ExtractCarrier.
+package kafkatrace
+
+import (
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HeadersCarrier adapts the headers of a kafka-go message to propagation.TextMapCarrier.
+type HeadersCarrier struct {
+	headers *[]kafka.Header
+}
+
+var _ propagation.TextMapCarrier = HeadersCarrier{}
+
+// NewHeadersCarrier creates a carrier for the given headers, usually &message.Headers.
+func NewHeadersCarrier(headers *[]kafka.Header) HeadersCarrier {
+	return HeadersCarrier{headers: headers}
+}
+
+// Get returns the value of the first header with the given key.
+func (c HeadersCarrier) Get(key string) string {
+	for _, header := range *c.headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the headers with the given key by a single header.
+func (c HeadersCarrier) Set(key, value string) {
+	headers := (*c.headers)[:0]
+	for _, header := range *c.headers {
+		if header.Key != key {
+			headers = append(headers, header)
+		}
+	}
+	*c.headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys lists the header keys.
+func (c HeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, header := range *c.headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
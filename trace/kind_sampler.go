@@ -0,0 +1,43 @@
+package trace
+
+import (
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// kindSampler applies per-kind sample rates to local root spans, those without a parent in
+// the process, so that a trace is kept or dropped as a whole. Other local roots use the
+// fallback sampler and child spans follow their parent.
+type kindSampler struct {
+	samplers map[oteltrace.SpanKind]sdktrace.Sampler
+	fallback sdktrace.Sampler
+	parent   sdktrace.Sampler
+}
+
+func newKindSampler(
+	rates map[oteltrace.SpanKind]float64,
+	ratio func(rate float64) sdktrace.Sampler,
+	fallback sdktrace.Sampler,
+) sdktrace.Sampler {
+	samplers := make(map[oteltrace.SpanKind]sdktrace.Sampler, len(rates))
+	for kind, rate := range rates {
+		samplers[oteltrace.ValidateSpanKind(kind)] = ratio(rate)
+	}
+	return &kindSampler{samplers: samplers, fallback: fallback, parent: sdktrace.ParentBased(fallback)}
+}
+
+func (s *kindSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if parent := oteltrace.SpanContextFromContext(p.ParentContext); parent.IsValid() && !parent.IsRemote() {
+		return s.parent.ShouldSample(p)
+	}
+	if sampler, ok := s.samplers[oteltrace.ValidateSpanKind(p.Kind)]; ok {
+		return sampler.ShouldSample(p)
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *kindSampler) Description() string {
+	return fmt.Sprintf("KindSampler{kinds=%d,fallback=%s}", len(s.samplers), s.fallback.Description())
+}
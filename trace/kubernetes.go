@@ -0,0 +1,113 @@
+package trace
+
+import (
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+const (
+	kubernetesServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+	kubernetesNamespaceFile  = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	// pod-template-hash and the random pod name suffix use this alphabet (k8s.io/apimachinery rand)
+	kubernetesNameAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+)
+
+// Environment variables read for the Kubernetes resource attributes, in order. Expose them
+// with the downward API, e.g. K8S_NODE_NAME from spec.nodeName.
+var (
+	kubernetesPodNameEnvs        = []string{"K8S_POD_NAME", "POD_NAME"}
+	kubernetesNamespaceNameEnvs  = []string{"K8S_NAMESPACE_NAME", "POD_NAMESPACE"}
+	kubernetesNodeNameEnvs       = []string{"K8S_NODE_NAME", "NODE_NAME"}
+	kubernetesDeploymentNameEnvs = []string{"K8S_DEPLOYMENT_NAME", "DEPLOYMENT_NAME"}
+)
+
+// kubernetesAttributes detects k8s.pod.name, k8s.namespace.name, k8s.node.name and
+// k8s.deployment.name when the process runs in a Kubernetes pod. Downward API environment
+// variables take precedence. Without them, the pod name falls back to the hostname, the
+// namespace to the service account file, and the deployment name is derived from a pod
+// name of the form <deployment>-<pod-template-hash>-<suffix>. The node name is only
+// available through the downward API.
+func kubernetesAttributes() []attribute.KeyValue {
+	podName := firstEnv(kubernetesPodNameEnvs)
+	namespace := firstEnv(kubernetesNamespaceNameEnvs)
+	nodeName := firstEnv(kubernetesNodeNameEnvs)
+	deployment := firstEnv(kubernetesDeploymentNameEnvs)
+
+	_, inCluster := os.LookupEnv(kubernetesServiceHostEnv)
+	if !inCluster && podName == "" && namespace == "" && nodeName == "" && deployment == "" {
+		return nil
+	}
+
+	if podName == "" && inCluster {
+		podName, _ = os.Hostname()
+	}
+	if namespace == "" {
+		if b, err := os.ReadFile(kubernetesNamespaceFile); err == nil {
+			namespace = strings.TrimSpace(string(b))
+		}
+	}
+	if deployment == "" {
+		deployment = deploymentFromPodName(podName)
+	}
+
+	var attrs []attribute.KeyValue
+	if podName != "" {
+		attrs = append(attrs, semconv.K8SPodName(podName))
+	}
+	if namespace != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(namespace))
+	}
+	if nodeName != "" {
+		attrs = append(attrs, semconv.K8SNodeName(nodeName))
+	}
+	if deployment != "" {
+		attrs = append(attrs, semconv.K8SDeploymentName(deployment))
+	}
+	return attrs
+}
+
+// deploymentFromPodName returns the deployment of a pod created by a deployment's replica
+// set, or "" when the name does not end with a pod-template-hash and a 5 character suffix.
+func deploymentFromPodName(podName string) string {
+	rest, suffix, ok := cutLast(podName, "-")
+	if !ok || len(suffix) != 5 || !isKubernetesRandom(suffix) {
+		return ""
+	}
+	deployment, hash, ok := cutLast(rest, "-")
+	if !ok || deployment == "" || len(hash) > 10 || !isKubernetesRandom(hash) {
+		return ""
+	}
+	return deployment
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func isKubernetesRandom(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune(kubernetesNameAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func firstEnv(names []string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
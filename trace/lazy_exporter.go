@@ -0,0 +1,101 @@
+package trace
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	lazyExporterInitialRetryDelay = time.Second
+	lazyExporterMaxRetryDelay     = time.Minute
+)
+
+// lazyExporter creates its exporter in the background, retrying with exponential backoff
+// until it succeeds or the exporter is shut down. Spans exported meanwhile are dropped,
+// so an unreachable collector degrades tracing instead of failing the application.
+type lazyExporter struct {
+	create func() (sdktrace.SpanExporter, error)
+
+	mu       sync.RWMutex
+	exporter sdktrace.SpanExporter
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+var _ sdktrace.SpanExporter = (*lazyExporter)(nil)
+
+func newLazyExporter(create func() (sdktrace.SpanExporter, error)) *lazyExporter {
+	e := &lazyExporter{
+		create: create,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go e.connect()
+	return e
+}
+
+func (e *lazyExporter) connect() {
+	defer close(e.done)
+
+	logger := slog.Default()
+	delay := lazyExporterInitialRetryDelay
+	for {
+		exporter, err := e.create()
+		if err == nil {
+			e.mu.Lock()
+			e.exporter = exporter
+			e.mu.Unlock()
+			return
+		}
+
+		logger.Warn("Failed to create trace exporter, spans are dropped until it succeeds",
+			"error", err, "retry_in", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-e.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, lazyExporterMaxRetryDelay)
+	}
+}
+
+// ExportSpans exports spans once the exporter is created, and drops them before.
+func (e *lazyExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.RLock()
+	exporter := e.exporter
+	e.mu.RUnlock()
+
+	if exporter == nil {
+		return nil
+	}
+	return exporter.ExportSpans(ctx, spans)
+}
+
+// Shutdown stops the retries and shuts down the exporter if it was created.
+func (e *lazyExporter) Shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.stop) })
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	e.mu.RLock()
+	exporter := e.exporter
+	e.mu.RUnlock()
+
+	if exporter == nil {
+		return nil
+	}
+	return exporter.Shutdown(ctx)
+}
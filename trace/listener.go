@@ -0,0 +1,237 @@
+package trace
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	connectionSpanName = "net.connection"
+	tlsHandshakeEvent  = "tls.handshake"
+
+	connectionsAcceptedName  = "net.server.connections.accepted"
+	connectionsActiveName    = "net.server.connections.active"
+	connectionDurationName   = "net.server.connection.duration"
+	tlsHandshakeDurationName = "tls.server.handshake.duration"
+
+	connectionBytesReadKey    = attribute.Key("net.connection.bytes.read")
+	connectionBytesWrittenKey = attribute.Key("net.connection.bytes.written")
+	tlsHandshakeDurationKey   = attribute.Key("tls.handshake.duration_ms")
+)
+
+// ListenerConfig configures WrapListener.
+type ListenerConfig struct {
+	// TLSConfig makes the listener serve TLS like tls.NewListener, so handshake durations
+	// can be measured. A GetConfigForClient callback returning another configuration
+	// bypasses the measurement.
+	TLSConfig *tls.Config
+	// ConnectionSpans starts a span per connection, from accept to close. Use ConnContext
+	// to create the spans of the connection's requests under it.
+	ConnectionSpans bool
+}
+
+// WrapListener records accepted and active connections, connection lifetimes and TLS
+// handshake durations as metrics through the metric package, and optionally a span per
+// connection, for servers of custom TCP protocols. Metrics are recorded once the metric
+// package is initialized.
+func WrapListener(l net.Listener, config ListenerConfig) net.Listener {
+	return &tracedListener{Listener: l, config: config, instruments: &listenerInstruments{}}
+}
+
+type tracedListener struct {
+	net.Listener
+	config      ListenerConfig
+	instruments *listenerInstruments
+}
+
+func (l *tracedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	instruments := l.instruments.get()
+	ctx := context.Background()
+	instruments.accepted.Add(ctx, 1)
+	instruments.active.Add(ctx, 1)
+
+	tc := &tracedConn{Conn: conn, instruments: instruments, start: time.Now(), ctx: ctx}
+	if l.config.ConnectionSpans {
+		tc.ctx, tc.span = Span(ctx, connectionSpanName,
+			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+			oteltrace.WithAttributes(
+				semconv.NetworkTransportTCP,
+				semconv.NetworkPeerAddress(conn.RemoteAddr().String()),
+				semconv.NetworkLocalAddress(conn.LocalAddr().String()),
+			),
+		)
+	}
+
+	if l.config.TLSConfig == nil {
+		return tc, nil
+	}
+
+	tlsConfig := l.config.TLSConfig.Clone()
+	verifyConnection := tlsConfig.VerifyConnection
+	tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+		tc.handshakeDone(state)
+		if verifyConnection != nil {
+			return verifyConnection(state)
+		}
+		return nil
+	}
+	return tls.Server(tc, tlsConfig), nil
+}
+
+// ConnContext returns a context carrying the span of a connection accepted by a listener
+// created with WrapListener and ConnectionSpans, or context.Background() for other
+// connections. It suits http.Server.ConnContext as well.
+func ConnContext(conn net.Conn) context.Context {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+	if tc, ok := conn.(*tracedConn); ok {
+		return tc.ctx
+	}
+	return context.Background()
+}
+
+// tracedConn records the lifetime of a connection and the duration of its TLS handshake,
+// measured from the first bytes received (the ClientHello) to the end of verification.
+type tracedConn struct {
+	net.Conn
+
+	instruments *connInstruments
+	ctx         context.Context
+	span        oteltrace.Span
+	start       time.Time
+
+	firstRead    atomic.Int64 // unix nanoseconds
+	bytesRead    atomic.Int64
+	bytesWritten atomic.Int64
+	closeOnce    sync.Once
+}
+
+func (c *tracedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.firstRead.CompareAndSwap(0, time.Now().UnixNano())
+		c.bytesRead.Add(int64(n))
+	}
+	return n, err
+}
+
+func (c *tracedConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.bytesWritten.Add(int64(n))
+	return n, err
+}
+
+func (c *tracedConn) handshakeDone(state tls.ConnectionState) {
+	firstRead := c.firstRead.Load()
+	if firstRead == 0 {
+		return
+	}
+	duration := time.Since(time.Unix(0, firstRead))
+	version := semconv.TLSProtocolVersion(strings.TrimPrefix(tls.VersionName(state.Version), "TLS "))
+
+	c.instruments.handshake.Record(context.Background(), duration.Seconds(), otelmetric.WithAttributes(version))
+	if c.span != nil {
+		c.span.AddEvent(tlsHandshakeEvent, oteltrace.WithAttributes(
+			version,
+			tlsHandshakeDurationKey.Float64(float64(duration)/float64(time.Millisecond)),
+		))
+	}
+}
+
+func (c *tracedConn) Close() error {
+	c.closeOnce.Do(func() {
+		ctx := context.Background()
+		c.instruments.active.Add(ctx, -1)
+		c.instruments.duration.Record(ctx, time.Since(c.start).Seconds())
+		if c.span != nil {
+			c.span.SetAttributes(
+				connectionBytesReadKey.Int64(c.bytesRead.Load()),
+				connectionBytesWrittenKey.Int64(c.bytesWritten.Load()),
+			)
+			c.span.End()
+		}
+	})
+	return c.Conn.Close()
+}
+
+// connInstruments are the connection instruments of one meter.
+type connInstruments struct {
+	accepted  otelmetric.Int64Counter
+	active    otelmetric.Int64UpDownCounter
+	duration  otelmetric.Float64Histogram
+	handshake otelmetric.Float64Histogram
+}
+
+// listenerInstruments holds the connection instruments of the current global meter.
+// Connections keep the instruments they were accepted with.
+type listenerInstruments struct {
+	mu      sync.Mutex
+	meter   otelmetric.Meter
+	current *connInstruments
+}
+
+// get returns the instruments of the current global meter, recreating them when the
+// metric package was (re)initialized. Instruments that fail to be created are no-ops.
+func (i *listenerInstruments) get() *connInstruments {
+	meter := metric.Meter()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if meter == i.meter && i.current != nil {
+		return i.current
+	}
+
+	current := &connInstruments{}
+	var err error
+	if current.accepted, err = meter.Int64Counter(
+		connectionsAcceptedName,
+		otelmetric.WithDescription("Number of accepted connections"),
+		otelmetric.WithUnit("{connection}"),
+	); err != nil {
+		current.accepted = noop.Int64Counter{}
+	}
+	if current.active, err = meter.Int64UpDownCounter(
+		connectionsActiveName,
+		otelmetric.WithDescription("Number of open accepted connections"),
+		otelmetric.WithUnit("{connection}"),
+	); err != nil {
+		current.active = noop.Int64UpDownCounter{}
+	}
+	if current.duration, err = meter.Float64Histogram(
+		connectionDurationName,
+		otelmetric.WithDescription("Lifetime of accepted connections"),
+		otelmetric.WithUnit("s"),
+	); err != nil {
+		current.duration = noop.Float64Histogram{}
+	}
+	if current.handshake, err = meter.Float64Histogram(
+		tlsHandshakeDurationName,
+		otelmetric.WithDescription("Duration of server TLS handshakes"),
+		otelmetric.WithUnit("s"),
+	); err != nil {
+		current.handshake = noop.Float64Histogram{}
+	}
+
+	i.meter = meter
+	i.current = current
+	return current
+}
@@ -0,0 +1,87 @@
+// Package logrtrace correlates logr logs with traces, for controller-runtime and other
+// logr-based ecosystems where the slog integration of the logs package is not used.
+package logrtrace
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+// Logger returns logger with a sink adding the trace_id and span_id of the span in ctx to
+// every log line, or logger itself when ctx has no valid span or logger no sink.
+func Logger(ctx context.Context, logger logr.Logger) logr.Logger {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || logger.GetSink() == nil {
+		return logger
+	}
+	return logr.New(NewLogSink(logger.GetSink(), sc)).V(logger.GetV())
+}
+
+// FromContext returns the logger of ctx, as stored by logr.NewContext, with the trace_id
+// and span_id of the span in ctx. It returns a discarding logger when ctx has no logger.
+func FromContext(ctx context.Context) logr.Logger {
+	return Logger(ctx, logr.FromContextOrDiscard(ctx))
+}
+
+// NewLogSink wraps sink so that every log line carries the trace_id and span_id of sc.
+// Call site attribution is preserved for sinks implementing logr.CallDepthLogSink.
+func NewLogSink(sink logr.LogSink, sc oteltrace.SpanContext) logr.LogSink {
+	if withCallDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = withCallDepth.WithCallDepth(1)
+	}
+	return &traceSink{sink: sink, spanContext: sc}
+}
+
+// traceSink appends the trace and span IDs to the key/value pairs of every log line.
+type traceSink struct {
+	sink        logr.LogSink
+	spanContext oteltrace.SpanContext
+}
+
+// Init is a no-op: the wrapped sink was initialized by the logger it comes from.
+func (s *traceSink) Init(logr.RuntimeInfo) {}
+
+func (s *traceSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *traceSink) Info(level int, msg string, keysAndValues ...any) {
+	s.sink.Info(level, msg, s.withIDs(keysAndValues)...)
+}
+
+func (s *traceSink) Error(err error, msg string, keysAndValues ...any) {
+	s.sink.Error(err, msg, s.withIDs(keysAndValues)...)
+}
+
+func (s *traceSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &traceSink{sink: s.sink.WithValues(keysAndValues...), spanContext: s.spanContext}
+}
+
+func (s *traceSink) WithName(name string) logr.LogSink {
+	return &traceSink{sink: s.sink.WithName(name), spanContext: s.spanContext}
+}
+
+// WithCallDepth implements logr.CallDepthLogSink when the wrapped sink does.
+func (s *traceSink) WithCallDepth(depth int) logr.LogSink {
+	withCallDepth, ok := s.sink.(logr.CallDepthLogSink)
+	if !ok {
+		return s
+	}
+	return &traceSink{sink: withCallDepth.WithCallDepth(depth), spanContext: s.spanContext}
+}
+
+func (s *traceSink) withIDs(keysAndValues []any) []any {
+	kv := make([]any, 0, len(keysAndValues)+4)
+	kv = append(kv, keysAndValues...)
+	return append(kv,
+		traceIDKey, s.spanContext.TraceID().String(),
+		spanIDKey, s.spanContext.SpanID().String(),
+	)
+}
@@ -0,0 +1,42 @@
+// Package logrustrace correlates logrus logs with traces through a hook adding the trace
+// and span IDs of the entry's context to its fields.
+package logrustrace
+
+import (
+	"github.com/sirupsen/logrus"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+// Hook adds the trace_id and span_id fields to entries whose context, as set with
+// logrus.WithContext, carries a valid span. Add it with logger.AddHook(logrustrace.Hook{}).
+type Hook struct {
+	// Levels the hook fires for, all levels when empty.
+	LogLevels []logrus.Level
+}
+
+// Levels implements logrus.Hook.
+func (h Hook) Levels() []logrus.Level {
+	if len(h.LogLevels) == 0 {
+		return logrus.AllLevels
+	}
+	return h.LogLevels
+}
+
+// Fire implements logrus.Hook.
+func (h Hook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	sc := oteltrace.SpanContextFromContext(entry.Context)
+	if !sc.IsValid() {
+		return nil
+	}
+	entry.Data[traceIDKey] = sc.TraceID().String()
+	entry.Data[spanIDKey] = sc.SpanID().String()
+	return nil
+}
@@ -0,0 +1,152 @@
+// Package memcachetrace wraps a gomemcache client so that cache operations emit spans
+// through the global tracer configured by the trace package.
+package memcachetrace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	operationGet      = "get"
+	operationGetMulti = "get_multi"
+	operationSet      = "set"
+	operationAdd      = "add"
+	operationReplace  = "replace"
+	operationDelete   = "delete"
+	operationTouch    = "touch"
+
+	eventHit  = "cache.hit"
+	eventMiss = "cache.miss"
+
+	keyCountKey  = attribute.Key("db.memcached.key_count")
+	hitCountKey  = attribute.Key("db.memcached.hit_count")
+	missCountKey = attribute.Key("db.memcached.miss_count")
+)
+
+// Client wraps a *memcache.Client and traces every operation.
+// Methods mirror the memcache.Client API with an additional leading context.
+type Client struct {
+	client *memcache.Client
+}
+
+// NewClient wraps the given memcache client.
+func NewClient(client *memcache.Client) *Client {
+	return &Client{client: client}
+}
+
+// Unwrap returns the underlying memcache client.
+func (c *Client) Unwrap() *memcache.Client {
+	return c.client
+}
+
+// Get gets the item for the given key, recording a hit or miss event.
+func (c *Client) Get(ctx context.Context, key string) (*memcache.Item, error) {
+	_, span := startSpan(ctx, operationGet, 1)
+	defer span.End()
+
+	item, err := c.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		span.AddEvent(eventMiss)
+		return item, err
+	}
+	if err != nil {
+		recordError(span, err)
+		return item, err
+	}
+
+	span.AddEvent(eventHit)
+	return item, nil
+}
+
+// GetMulti gets the items for the given keys, recording hit and miss counts.
+func (c *Client) GetMulti(ctx context.Context, keys []string) (map[string]*memcache.Item, error) {
+	_, span := startSpan(ctx, operationGetMulti, len(keys))
+	defer span.End()
+
+	items, err := c.client.GetMulti(keys)
+	if err != nil {
+		recordError(span, err)
+		return items, err
+	}
+
+	hits := len(items)
+	misses := len(keys) - hits
+	span.SetAttributes(hitCountKey.Int(hits), missCountKey.Int(misses))
+	if hits > 0 {
+		span.AddEvent(eventHit, oteltrace.WithAttributes(hitCountKey.Int(hits)))
+	}
+	if misses > 0 {
+		span.AddEvent(eventMiss, oteltrace.WithAttributes(missCountKey.Int(misses)))
+	}
+
+	return items, nil
+}
+
+// Set writes the given item unconditionally.
+func (c *Client) Set(ctx context.Context, item *memcache.Item) error {
+	return c.do(ctx, operationSet, func() error { return c.client.Set(item) })
+}
+
+// Add writes the given item if no value already exists for its key.
+func (c *Client) Add(ctx context.Context, item *memcache.Item) error {
+	return c.do(ctx, operationAdd, func() error { return c.client.Add(item) })
+}
+
+// Replace writes the given item only if the server already holds data for its key.
+func (c *Client) Replace(ctx context.Context, item *memcache.Item) error {
+	return c.do(ctx, operationReplace, func() error { return c.client.Replace(item) })
+}
+
+// Delete deletes the item with the given key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.do(ctx, operationDelete, func() error { return c.client.Delete(key) })
+}
+
+// Touch updates the expiry for the given key.
+func (c *Client) Touch(ctx context.Context, key string, seconds int32) error {
+	return c.do(ctx, operationTouch, func() error { return c.client.Touch(key, seconds) })
+}
+
+// do traces a single-key operation. A cache miss is recorded as an event, not as an error.
+func (c *Client) do(ctx context.Context, operation string, fn func() error) error {
+	_, span := startSpan(ctx, operation, 1)
+	defer span.End()
+
+	err := fn()
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		span.AddEvent(eventMiss)
+		return err
+	}
+	if err != nil {
+		recordError(span, err)
+	}
+	return err
+}
+
+// startSpan starts a client span for the given memcached operation.
+func startSpan(ctx context.Context, operation string, keyCount int) (context.Context, oteltrace.Span) {
+	//nolint:spancheck // span is returned to caller who is responsible for ending it
+	return trace.Span(
+		ctx,
+		"memcached "+operation,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			semconv.DBSystemNameMemcached,
+			semconv.DBOperationName(operation),
+			keyCountKey.Int(keyCount),
+		),
+	)
+}
+
+func recordError(span oteltrace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
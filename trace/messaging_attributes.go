@@ -0,0 +1,53 @@
+package trace
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// MessagingInfo describes a messaging operation for SetMessagingAttributes. Empty fields
+// are not recorded.
+type MessagingInfo struct {
+	System      string // messaging.system, e.g. "kafka" or "rabbitmq"
+	Destination string // messaging.destination.name, the topic or queue
+	// Operation is messaging.operation.name. The semantic operation types "create", "send",
+	// "receive", "process" and "settle" are also recorded as messaging.operation.type.
+	Operation   string
+	MessageID   string // messaging.message.id
+	PayloadSize int    // messaging.message.body.size in bytes, 0 is not recorded
+}
+
+var messagingOperationTypes = map[string]attribute.KeyValue{
+	semconv.MessagingOperationTypeCreate.Value.AsString():  semconv.MessagingOperationTypeCreate,
+	semconv.MessagingOperationTypeSend.Value.AsString():    semconv.MessagingOperationTypeSend,
+	semconv.MessagingOperationTypeReceive.Value.AsString(): semconv.MessagingOperationTypeReceive,
+	semconv.MessagingOperationTypeProcess.Value.AsString(): semconv.MessagingOperationTypeProcess,
+	semconv.MessagingOperationTypeSettle.Value.AsString():  semconv.MessagingOperationTypeSettle,
+}
+
+// SetMessagingAttributes sets the messaging semantic convention attributes described by
+// info on span, for teams instrumenting brokers without a built-in integration.
+func SetMessagingAttributes(span oteltrace.Span, info MessagingInfo) {
+	var attrs []attribute.KeyValue
+	if info.System != "" {
+		attrs = append(attrs, semconv.MessagingSystemKey.String(info.System))
+	}
+	if info.Destination != "" {
+		attrs = append(attrs, semconv.MessagingDestinationName(info.Destination))
+	}
+	if info.Operation != "" {
+		attrs = append(attrs, semconv.MessagingOperationName(info.Operation))
+		if operationType, ok := messagingOperationTypes[info.Operation]; ok {
+			attrs = append(attrs, operationType)
+		}
+	}
+	if info.MessageID != "" {
+		attrs = append(attrs, semconv.MessagingMessageID(info.MessageID))
+	}
+	if info.PayloadSize > 0 {
+		attrs = append(attrs, semconv.MessagingMessageBodySize(info.PayloadSize))
+	}
+
+	span.SetAttributes(attrs...)
+}
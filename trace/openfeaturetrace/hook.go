@@ -0,0 +1,63 @@
+// Package openfeaturetrace provides an OpenFeature hook that records feature flag
+// evaluations as events on the active span, so traces show which flags were in effect.
+package openfeaturetrace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const eventName = "feature_flag.evaluation"
+
+// Hook implements openfeature.Hook. Register it globally with openfeature.AddHooks
+// or on a single client with Client.AddHooks.
+type Hook struct {
+	openfeature.UnimplementedHook
+}
+
+var _ openfeature.Hook = (*Hook)(nil)
+
+// NewHook creates a new feature flag evaluation hook.
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+// Finally adds a feature_flag.evaluation event with the flag key, variant and reason to
+// the span in ctx. Evaluations that failed also carry the error type.
+func (h *Hook) Finally(
+	ctx context.Context,
+	hookContext openfeature.HookContext,
+	details openfeature.InterfaceEvaluationDetails,
+	_ openfeature.HookHints,
+) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.FeatureFlagKey(hookContext.FlagKey()),
+	}
+	if name := hookContext.ProviderMetadata().Name; name != "" {
+		attrs = append(attrs, semconv.FeatureFlagProviderName(name))
+	}
+	if details.Variant != "" {
+		attrs = append(attrs, semconv.FeatureFlagResultVariant(details.Variant))
+	}
+	if details.Reason != "" {
+		attrs = append(attrs, semconv.FeatureFlagResultReasonKey.String(strings.ToLower(string(details.Reason))))
+	}
+	if targetingKey := hookContext.EvaluationContext().TargetingKey(); targetingKey != "" {
+		attrs = append(attrs, semconv.FeatureFlagContextID(targetingKey))
+	}
+	if details.ErrorCode != "" {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(strings.ToLower(string(details.ErrorCode))))
+	}
+
+	span.AddEvent(eventName, oteltrace.WithAttributes(attrs...))
+}
@@ -0,0 +1,31 @@
+package trace
+
+import (
+	"log/slog"
+
+	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel"
+	otbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// installOpenTracingBridge registers an OpenTracing tracer backed by tp as the
+// opentracing-go global tracer. It returns the provider that must be used to create
+// OpenTelemetry spans so they interoperate with spans started through OpenTracing.
+func installOpenTracingBridge(tp oteltrace.TracerProvider, appName string) oteltrace.TracerProvider {
+	bridge := otbridge.NewBridgeTracer()
+	bridge.SetOpenTelemetryTracer(tp.Tracer(appName))
+	bridge.SetTextMapPropagator(otel.GetTextMapPropagator())
+	bridge.SetWarningHandler(func(msg string) {
+		slog.Default().Warn("OpenTracing bridge warning", "message", msg)
+	})
+
+	opentracing.SetGlobalTracer(bridge)
+
+	return otbridge.NewTracerProvider(bridge, tp)
+}
+
+// uninstallOpenTracingBridge restores the opentracing-go no-op global tracer.
+func uninstallOpenTracingBridge() {
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+}
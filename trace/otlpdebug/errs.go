@@ -0,0 +1,9 @@
+package otlpdebug
+
+import "errors"
+
+var (
+	ErrNoListenAddress = errors.New("no gRPC or HTTP listen address configured")
+	ErrListen          = errors.New("failed to listen")
+	ErrDecodeRequest   = errors.New("failed to decode export request")
+)
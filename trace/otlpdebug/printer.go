@@ -0,0 +1,197 @@
+package otlpdebug
+
+import (
+	"cmp"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const (
+	serviceNameKey = "service.name"
+	unknownService = "unknown_service"
+
+	branch     = "├─ "
+	lastBranch = "└─ "
+	pipe       = "│  "
+	blank      = "   "
+)
+
+// spanNode is a span with the children received in the same export request.
+type spanNode struct {
+	span     *tracepb.Span
+	children []*spanNode
+}
+
+// writeTrees writes one tree per trace and service of req. Spans whose parent was not
+// part of the request, usually because it is exported later, are printed as roots.
+func writeTrees(w io.Writer, req *coltracepb.ExportTraceServiceRequest, attributes bool) {
+	for _, resourceSpans := range req.GetResourceSpans() {
+		service := serviceName(resourceSpans.GetResource().GetAttributes())
+
+		var spans []*tracepb.Span
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			spans = append(spans, scopeSpans.GetSpans()...)
+		}
+
+		for _, traceSpans := range groupByTrace(spans) {
+			fmt.Fprintf(w, "trace %s (%s)\n", hex.EncodeToString(traceSpans[0].GetTraceId()), service)
+			roots := buildTree(traceSpans)
+			for i, root := range roots {
+				writeNode(w, root, "", i == len(roots)-1, attributes)
+			}
+		}
+	}
+}
+
+func serviceName(attrs []*commonpb.KeyValue) string {
+	for _, kv := range attrs {
+		if kv.GetKey() == serviceNameKey {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return unknownService
+}
+
+// groupByTrace groups spans by trace ID, in order of first appearance.
+func groupByTrace(spans []*tracepb.Span) [][]*tracepb.Span {
+	var groups [][]*tracepb.Span
+	index := make(map[string]int)
+	for _, span := range spans {
+		traceID := string(span.GetTraceId())
+		i, ok := index[traceID]
+		if !ok {
+			i = len(groups)
+			index[traceID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], span)
+	}
+	return groups
+}
+
+func buildTree(spans []*tracepb.Span) []*spanNode {
+	nodes := make(map[string]*spanNode, len(spans))
+	for _, span := range spans {
+		nodes[string(span.GetSpanId())] = &spanNode{span: span}
+	}
+
+	var roots []*spanNode
+	for _, span := range spans {
+		node := nodes[string(span.GetSpanId())]
+		if parent, ok := nodes[string(span.GetParentSpanId())]; ok && parent != node {
+			parent.children = append(parent.children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	sortByStart(roots)
+	for _, node := range nodes {
+		sortByStart(node.children)
+	}
+	return roots
+}
+
+func sortByStart(nodes []*spanNode) {
+	slices.SortStableFunc(nodes, func(a, b *spanNode) int {
+		return cmp.Compare(a.span.GetStartTimeUnixNano(), b.span.GetStartTimeUnixNano())
+	})
+}
+
+func writeNode(w io.Writer, node *spanNode, prefix string, last bool, attributes bool) {
+	connector, childPrefix := branch, prefix+pipe
+	if last {
+		connector, childPrefix = lastBranch, prefix+blank
+	}
+
+	fmt.Fprintf(w, "%s%s%s\n", prefix, connector, spanLine(node.span))
+
+	if attributes {
+		detailPrefix := childPrefix + blank
+		if len(node.children) > 0 {
+			detailPrefix = childPrefix + pipe
+		}
+		for _, kv := range node.span.GetAttributes() {
+			fmt.Fprintf(w, "%s%s=%s\n", detailPrefix, kv.GetKey(), formatValue(kv.GetValue()))
+		}
+		for _, event := range node.span.GetEvents() {
+			fmt.Fprintf(w, "%sevent %s%s\n", detailPrefix, event.GetName(), formatAttributes(event.GetAttributes()))
+		}
+	}
+
+	for i, child := range node.children {
+		writeNode(w, child, childPrefix, i == len(node.children)-1, attributes)
+	}
+}
+
+// spanLine formats the name, duration, kind and error status of a span.
+func spanLine(span *tracepb.Span) string {
+	var b strings.Builder
+	b.WriteString(span.GetName())
+
+	duration := time.Duration(span.GetEndTimeUnixNano() - span.GetStartTimeUnixNano())
+	b.WriteString(" " + duration.String())
+
+	switch kind := span.GetKind(); kind {
+	case tracepb.Span_SPAN_KIND_UNSPECIFIED, tracepb.Span_SPAN_KIND_INTERNAL:
+	default:
+		b.WriteString(" [" + strings.ToLower(strings.TrimPrefix(kind.String(), "SPAN_KIND_")) + "]")
+	}
+
+	if status := span.GetStatus(); status.GetCode() == tracepb.Status_STATUS_CODE_ERROR {
+		b.WriteString(" ERROR")
+		if status.GetMessage() != "" {
+			b.WriteString(": " + status.GetMessage())
+		}
+	}
+	return b.String()
+}
+
+func formatAttributes(attrs []*commonpb.KeyValue) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(attrs))
+	for _, kv := range attrs {
+		parts = append(parts, kv.GetKey()+"="+formatValue(kv.GetValue()))
+	}
+	return " {" + strings.Join(parts, ", ") + "}"
+}
+
+func formatValue(value *commonpb.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return strconv.Quote(v.StringValue)
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+	case *commonpb.AnyValue_BytesValue:
+		return hex.EncodeToString(v.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := v.ArrayValue.GetValues()
+		parts := make([]string, 0, len(values))
+		for _, item := range values {
+			parts = append(parts, formatValue(item))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *commonpb.AnyValue_KvlistValue:
+		if len(v.KvlistValue.GetValues()) == 0 {
+			return "{}"
+		}
+		return strings.TrimPrefix(formatAttributes(v.KvlistValue.GetValues()), " ")
+	default:
+		return ""
+	}
+}
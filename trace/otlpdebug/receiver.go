@@ -0,0 +1,226 @@
+// Package otlpdebug provides a minimal OTLP trace receiver for local development. It
+// accepts spans over OTLP/gRPC and OTLP/HTTP, prints them as trees and can append the
+// raw requests to a file, so traces can be inspected without running a collector.
+package otlpdebug
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip" // accept gzip compressed gRPC exports
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultGRPCAddr = "localhost:4317"
+	DefaultHTTPAddr = "localhost:4318"
+
+	tracesPath         = "/v1/traces"
+	maxRequestBodySize = 32 << 20
+	shutdownTimeout    = 5 * time.Second
+)
+
+// Config configures the receiver.
+type Config struct {
+	// GRPCAddr is the OTLP/gRPC listen address, not served when empty.
+	GRPCAddr string
+	// HTTPAddr is the OTLP/HTTP listen address, not served when empty.
+	HTTPAddr string
+	// Output receives the span trees, os.Stdout when nil.
+	Output io.Writer
+	// File, when set, receives every export request as one line of OTLP JSON.
+	File io.Writer
+	// Attributes prints span attributes and events below each span.
+	Attributes bool
+}
+
+// Receiver is an OTLP trace receiver printing the spans it receives.
+type Receiver struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	config Config
+	mu     sync.Mutex
+}
+
+var _ coltracepb.TraceServiceServer = (*Receiver)(nil)
+
+// NewReceiver creates a new receiver.
+func NewReceiver(config Config) *Receiver {
+	if config.Output == nil {
+		config.Output = os.Stdout
+	}
+	return &Receiver{config: config}
+}
+
+// ListenAndServe serves the configured addresses until ctx is done, then stops the
+// servers gracefully.
+func (r *Receiver) ListenAndServe(ctx context.Context) error {
+	if r.config.GRPCAddr == "" && r.config.HTTPAddr == "" {
+		return ErrNoListenAddress
+	}
+
+	var grpcListener, httpListener net.Listener
+	var err error
+	if r.config.GRPCAddr != "" {
+		grpcListener, err = net.Listen("tcp", r.config.GRPCAddr)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrListen, err)
+		}
+	}
+	if r.config.HTTPAddr != "" {
+		httpListener, err = net.Listen("tcp", r.config.HTTPAddr)
+		if err != nil {
+			if grpcListener != nil {
+				_ = grpcListener.Close()
+			}
+			return fmt.Errorf("%w: %w", ErrListen, err)
+		}
+	}
+
+	errCh := make(chan error, 2)
+	var grpcServer *grpc.Server
+	var httpServer *http.Server
+
+	if grpcListener != nil {
+		grpcServer = grpc.NewServer()
+		coltracepb.RegisterTraceServiceServer(grpcServer, r)
+		go func() { errCh <- grpcServer.Serve(grpcListener) }()
+	}
+	if httpListener != nil {
+		mux := http.NewServeMux()
+		mux.Handle(tracesPath, r)
+		httpServer = &http.Server{Handler: mux, ReadHeaderTimeout: shutdownTimeout}
+		go func() { errCh <- httpServer.Serve(httpListener) }()
+	}
+
+	select {
+	case <-ctx.Done():
+		err = nil
+	case err = <-errCh:
+	}
+
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+		err = errors.Join(err, httpServer.Shutdown(shutdownCtx))
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Export implements the OTLP/gRPC trace service.
+func (r *Receiver) Export(
+	_ context.Context,
+	req *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	if err := r.Handle(req); err != nil {
+		return nil, err
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// ServeHTTP implements the OTLP/HTTP trace endpoint for protobuf and JSON payloads.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	isJSON := strings.HasPrefix(req.Header.Get("Content-Type"), "application/json")
+
+	exportReq, err := decodeHTTPRequest(req, isJSON)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := r.Handle(exportReq); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var body []byte
+	if isJSON {
+		w.Header().Set("Content-Type", "application/json")
+		body, err = protojson.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	} else {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		body, err = proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+func decodeHTTPRequest(req *http.Request, isJSON bool) (*coltracepb.ExportTraceServiceRequest, error) {
+	var reader io.Reader = http.MaxBytesReader(nil, req.Body, maxRequestBodySize)
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecodeRequest, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecodeRequest, err)
+	}
+
+	exportReq := &coltracepb.ExportTraceServiceRequest{}
+	if isJSON {
+		err = protojson.Unmarshal(body, exportReq)
+	} else {
+		err = proto.Unmarshal(body, exportReq)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecodeRequest, err)
+	}
+	return exportReq, nil
+}
+
+// Handle prints the spans of req and appends it to the file, if configured. Requests are
+// handled one at a time so trees of concurrent exports do not interleave.
+func (r *Receiver) Handle(req *coltracepb.ExportTraceServiceRequest) error {
+	var buf bytes.Buffer
+	writeTrees(&buf, req, r.config.Attributes)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.config.Output.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if r.config.File == nil {
+		return nil
+	}
+
+	line, err := protojson.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = r.config.File.Write(append(line, '\n'))
+	return err
+}
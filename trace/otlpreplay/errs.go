@@ -0,0 +1,10 @@
+package otlpreplay
+
+import "errors"
+
+var (
+	ErrEndpointRequired = errors.New("endpoint is required")
+	ErrConnect          = errors.New("failed to connect to the endpoint")
+	ErrDecodeLine       = errors.New("failed to decode OTLP JSON line")
+	ErrSend             = errors.New("failed to send spans")
+)
@@ -0,0 +1,178 @@
+// Package otlpreplay re-sends spans captured as OTLP JSON lines, the format written by
+// otlpdebug and the collector's file exporter, to an OTLP endpoint. It suits devices that
+// record traces to disk while offline and upload them later.
+package otlpreplay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Config configures the OTLP endpoint spans are replayed to.
+type Config struct {
+	// Endpoint is the host:port of the collector.
+	Endpoint string
+	// HTTP sends the spans over OTLP/HTTP instead of OTLP/gRPC.
+	HTTP bool
+	// Insecure disables TLS.
+	Insecure bool
+	// Headers are sent with every export request, e.g. API keys.
+	Headers map[string]string
+	// URLPath overrides the OTLP/HTTP traces path, default "/v1/traces".
+	URLPath string
+}
+
+// Stats counts what was replayed.
+type Stats struct {
+	Requests int
+	Spans    int
+}
+
+// Replayer sends OTLP JSON lines to an OTLP endpoint.
+type Replayer struct {
+	client otlptrace.Client
+}
+
+// NewReplayer connects to the endpoint of config.
+func NewReplayer(ctx context.Context, config Config) (*Replayer, error) {
+	if config.Endpoint == "" {
+		return nil, ErrEndpointRequired
+	}
+
+	client := newClient(config)
+	if err := client.Start(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConnect, err)
+	}
+	return &Replayer{client: client}, nil
+}
+
+func newClient(config Config) otlptrace.Client {
+	if config.HTTP {
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlptracehttp.WithHeaders(config.Headers))
+		}
+		if config.URLPath != "" {
+			options = append(options, otlptracehttp.WithURLPath(config.URLPath))
+		}
+		return otlptracehttp.NewClient(options...)
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	if len(config.Headers) > 0 {
+		options = append(options, otlptracegrpc.WithHeaders(config.Headers))
+	}
+	return otlptracegrpc.NewClient(options...)
+}
+
+// Replay sends every line of r, an export request in OTLP JSON, as one export request.
+// Empty lines are skipped. It stops at the first line that cannot be decoded or sent,
+// returning what was replayed before.
+func (p *Replayer) Replay(ctx context.Context, r io.Reader) (Stats, error) {
+	var stats Stats
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return stats, err
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			req, decodeErr := decodeRequest(data)
+			if decodeErr != nil {
+				return stats, fmt.Errorf("%w: line %d: %w", ErrDecodeLine, line, decodeErr)
+			}
+			if sendErr := p.client.UploadTraces(ctx, req.GetResourceSpans()); sendErr != nil {
+				return stats, fmt.Errorf("%w: line %d: %w", ErrSend, line, sendErr)
+			}
+			stats.Requests++
+			stats.Spans += spanCount(req)
+		}
+		if errors.Is(err, io.EOF) {
+			return stats, nil
+		}
+	}
+}
+
+// decodeRequest decodes a line of OTLP JSON. Trace and span IDs are accepted hex-encoded,
+// as the OTLP specification and the collector write them, or base64-encoded, as protojson
+// writes them.
+func decodeRequest(data []byte) (*coltracepb.ExportTraceServiceRequest, error) {
+	// UseNumber keeps 64-bit timestamps written as JSON numbers exact
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	hexIDsToBase64(doc)
+	normalized, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &coltracepb.ExportTraceServiceRequest{}
+	if err := protojson.Unmarshal(normalized, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// idLengths are the hex lengths of the ID fields of spans and links.
+var idLengths = map[string]int{
+	"traceId":      32,
+	"spanId":       16,
+	"parentSpanId": 16,
+}
+
+func hexIDsToBase64(node any) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if id, ok := value.(string); ok && len(id) == idLengths[key] {
+				if b, err := hex.DecodeString(id); err == nil {
+					v[key] = base64.StdEncoding.EncodeToString(b)
+				}
+				continue
+			}
+			hexIDsToBase64(value)
+		}
+	case []any:
+		for _, value := range v {
+			hexIDsToBase64(value)
+		}
+	}
+}
+
+// Close disconnects from the endpoint.
+func (p *Replayer) Close(ctx context.Context) error {
+	return p.client.Stop(ctx)
+}
+
+func spanCount(req *coltracepb.ExportTraceServiceRequest) int {
+	count := 0
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			count += len(ss.GetSpans())
+		}
+	}
+	return count
+}
@@ -0,0 +1,56 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const outboxDelayKey = attribute.Key("outbox.delay_ms")
+
+// OutboxTraceContext serializes the trace context and baggage of ctx, along with the
+// current time, as a JSON object to persist with an outbox record in the same transaction
+// as the business change. The relay resumes the trace from it with OutboxRelaySpan.
+func OutboxTraceContext(ctx context.Context) string {
+	carrier := map[string]string{}
+	Inject(ctx, carrier)
+	SetEnqueueTime(carrier, time.Now())
+
+	// a map of strings always encodes
+	b, _ := json.Marshal(carrier)
+	return string(b)
+}
+
+// OutboxRelaySpan starts a producer span for the relay publishing an outbox record whose
+// trace context was stored with OutboxTraceContext. The span continues the trace of the
+// code that wrote the record, links to the relay's current span in ctx, e.g. its polling
+// span, and records the time the record waited in the outbox as outbox.delay_ms. Inject
+// the returned context into the published message so consumers join the same trace.
+// A missing or malformed traceContext starts the span from ctx alone.
+func OutboxRelaySpan(
+	ctx context.Context,
+	traceContext string,
+	name string,
+	opts ...oteltrace.SpanStartOption,
+) (context.Context, oteltrace.Span) {
+	startOpts := []oteltrace.SpanStartOption{oteltrace.WithSpanKind(oteltrace.SpanKindProducer)}
+
+	var carrier map[string]string
+	if err := json.Unmarshal([]byte(traceContext), &carrier); err != nil || len(carrier) == 0 {
+		return Span(ctx, name, append(startOpts, opts...)...)
+	}
+
+	if relay := oteltrace.SpanContextFromContext(ctx); relay.IsValid() {
+		startOpts = append(startOpts, oteltrace.WithLinks(oteltrace.Link{SpanContext: relay}))
+	}
+	if stored, ok := enqueueTime(carrier); ok {
+		startOpts = append(startOpts, oteltrace.WithAttributes(outboxDelayKey.Int64(time.Since(stored).Milliseconds())))
+	}
+
+	// the stored context replaces the relay's span as parent, keeping the values of ctx
+	ctx = Extract(oteltrace.ContextWithSpanContext(ctx, oteltrace.SpanContext{}), carrier)
+	return Span(ctx, name, append(startOpts, opts...)...)
+}
@@ -0,0 +1,196 @@
+// Package pgxtrace implements the pgx v5 tracer interfaces with the global tracer
+// configured by the trace package: a client span per query, batch and connection
+// attempt, and pool acquire timing events.
+package pgxtrace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSpanName    = "postgresql"
+	batchOperationName = "BATCH"
+	connectSpanName    = "connect"
+
+	batchQueryEvent = "pgx.batch.query"
+	acquireEvent    = "pgxpool.acquire"
+
+	acquireDurationKey = attribute.Key("pgxpool.acquire.duration_ms")
+)
+
+// Tracer implements pgx.QueryTracer, pgx.BatchTracer, pgx.ConnectTracer and
+// pgxpool.AcquireTracer. Set it as ConnConfig.Tracer, also for pools. Statements are
+// sanitized with trace.SanitizeStatement and query arguments are never recorded.
+type Tracer struct{}
+
+var (
+	_ pgx.QueryTracer       = (*Tracer)(nil)
+	_ pgx.BatchTracer       = (*Tracer)(nil)
+	_ pgx.ConnectTracer     = (*Tracer)(nil)
+	_ pgxpool.AcquireTracer = (*Tracer)(nil)
+)
+
+// NewTracer creates a tracer backed by the global tracer.
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// acquireStartKey carries the start of a pool acquire.
+type acquireStartKey struct{}
+
+// TraceQueryStart starts a client span for the query.
+func (t *Tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := operationName(data.SQL)
+	ctx, span := startSpan(ctx, conn.Config(), operation)
+	trace.SetDBAttributes(span, trace.DBInfo{Operation: operation, Statement: data.SQL})
+	return ctx
+}
+
+// TraceQueryEnd ends the span of the query with the returned rows or the error.
+func (t *Tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := oteltrace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		recordError(ctx, data.Err)
+		return
+	}
+	if data.CommandTag.Select() {
+		span.SetAttributes(semconv.DBResponseReturnedRows(int(data.CommandTag.RowsAffected())))
+	}
+}
+
+// TraceBatchStart starts a client span for the batch.
+func (t *Tracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, span := startSpan(ctx, conn.Config(), batchOperationName)
+	span.SetAttributes(
+		semconv.DBOperationName(batchOperationName),
+		semconv.DBOperationBatchSize(data.Batch.Len()),
+	)
+	return ctx
+}
+
+// TraceBatchQuery adds a pgx.batch.query event with the statement of every query in the
+// batch and its error.
+func (t *Tracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attrs := []attribute.KeyValue{
+		semconv.DBOperationName(operationName(data.SQL)),
+		semconv.DBQueryText(trace.SanitizeStatement(data.SQL)),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, semconv.ErrorMessage(data.Err.Error()))
+	}
+	oteltrace.SpanFromContext(ctx).AddEvent(batchQueryEvent, oteltrace.WithAttributes(attrs...))
+}
+
+// TraceBatchEnd ends the span of the batch.
+func (t *Tracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	span := oteltrace.SpanFromContext(ctx)
+	defer span.End()
+
+	recordError(ctx, data.Err)
+}
+
+// TraceConnectStart starts a client span for establishing a connection.
+func (t *Tracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	ctx, _ = trace.Span(ctx, connectSpanName,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(connAttributes(data.ConnConfig)...),
+	)
+	return ctx
+}
+
+// TraceConnectEnd ends the span of the connection attempt.
+func (t *Tracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	span := oteltrace.SpanFromContext(ctx)
+	defer span.End()
+
+	recordError(ctx, data.Err)
+}
+
+// TraceAcquireStart notes the start of a pool acquire.
+func (t *Tracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+// TraceAcquireEnd adds a pgxpool.acquire event with the time spent waiting for a
+// connection, and the error, to the span in ctx.
+func (t *Tracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	start, ok := ctx.Value(acquireStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		acquireDurationKey.Float64(float64(time.Since(start)) / float64(time.Millisecond)),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, semconv.ErrorMessage(data.Err.Error()))
+	}
+	oteltrace.SpanFromContext(ctx).AddEvent(acquireEvent, oteltrace.WithAttributes(attrs...))
+}
+
+// startSpan starts a client span such as "SELECT orders" for an operation on the
+// database of config.
+func startSpan(ctx context.Context, config *pgx.ConnConfig, operation string) (context.Context, oteltrace.Span) {
+	//nolint:spancheck // the span is ended by the matching Trace*End call
+	return trace.Span(ctx, spanName(operation, config.Database),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(connAttributes(config)...),
+	)
+}
+
+func connAttributes(config *pgx.ConnConfig) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.DBSystemNamePostgreSQL}
+	if config == nil {
+		return attrs
+	}
+	if config.Database != "" {
+		attrs = append(attrs, semconv.DBNamespace(config.Database))
+	}
+	if config.Host != "" {
+		attrs = append(attrs, semconv.ServerAddress(config.Host), semconv.ServerPort(int(config.Port)))
+	}
+	return attrs
+}
+
+// recordError records err on the span in ctx with the PostgreSQL SQLSTATE code, if any.
+func recordError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		oteltrace.SpanFromContext(ctx).SetAttributes(semconv.DBResponseStatusCode(pgErr.Code))
+	}
+	trace.RecordError(ctx, err)
+}
+
+// operationName extracts the SQL command (SELECT, INSERT, ...) from a statement.
+func operationName(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// spanName builds a low-cardinality span name such as "SELECT orders".
+func spanName(operation, database string) string {
+	name := strings.TrimSpace(operation + " " + database)
+	if name == "" {
+		return defaultSpanName
+	}
+	return name
+}
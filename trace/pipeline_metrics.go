@@ -0,0 +1,145 @@
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	inFlightSpansName    = "trace.spans.in_flight"
+	queueUtilizationName = "trace.export.queue.utilization"
+)
+
+// pipelineMetrics tracks the number of open spans and of spans waiting in the batch
+// processor, and reports them as gauges through the metric package. The gauges are
+// registered once at Initialize on metric.Meter(), so the metric package is initialized
+// first.
+type pipelineMetrics struct {
+	inFlight atomic.Int64
+	queued   atomic.Int64
+	capacity int64
+
+	registration otelmetric.Registration // nil when registration failed
+}
+
+func newPipelineMetrics(capacity int) *pipelineMetrics {
+	return &pipelineMetrics{capacity: int64(capacity)}
+}
+
+// register registers the gauges on the meter of the metric package.
+func (m *pipelineMetrics) register() {
+	meter := metric.Meter()
+
+	inFlight, err := meter.Int64ObservableGauge(
+		inFlightSpansName,
+		otelmetric.WithDescription("Number of recording spans started and not yet ended"),
+		otelmetric.WithUnit("{span}"),
+	)
+	if err != nil {
+		return
+	}
+
+	utilization, err := meter.Float64ObservableGauge(
+		queueUtilizationName,
+		otelmetric.WithDescription("Fill ratio of the batch span processor queue, spans are dropped at 1"),
+		otelmetric.WithUnit("1"),
+	)
+	if err != nil {
+		return
+	}
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		o.ObserveInt64(inFlight, m.inFlight.Load())
+		o.ObserveFloat64(utilization, m.utilization())
+		return nil
+	}, inFlight, utilization)
+	if err == nil {
+		m.registration = registration
+	}
+}
+
+// unregister removes the gauges, so that a later Initialize does not report them twice.
+func (m *pipelineMetrics) unregister() {
+	if m.registration != nil {
+		_ = m.registration.Unregister()
+		m.registration = nil
+	}
+}
+
+func (m *pipelineMetrics) utilization() float64 {
+	if m.capacity <= 0 {
+		return 0
+	}
+	return float64(min(max(m.queued.Load(), 0), m.capacity)) / float64(m.capacity)
+}
+
+// inFlightProcessor counts recording spans between start and end.
+type inFlightProcessor struct {
+	metrics *pipelineMetrics
+}
+
+var _ sdktrace.SpanProcessor = (*inFlightProcessor)(nil)
+
+func (p *inFlightProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {
+	p.metrics.inFlight.Add(1)
+}
+
+func (p *inFlightProcessor) OnEnd(sdktrace.ReadOnlySpan) {
+	p.metrics.inFlight.Add(-1)
+}
+
+func (p *inFlightProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *inFlightProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// queueProcessor counts the sampled spans handed to the batch processor until the
+// exporter receives them. It drops spans itself once the queue capacity is reached,
+// as the batch processor would, so the count cannot drift from silent drops.
+type queueProcessor struct {
+	next    sdktrace.SpanProcessor
+	metrics *pipelineMetrics
+}
+
+var _ sdktrace.SpanProcessor = (*queueProcessor)(nil)
+
+func (p *queueProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *queueProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	if p.metrics.queued.Add(1) > p.metrics.capacity {
+		p.metrics.queued.Add(-1)
+		return
+	}
+	p.next.OnEnd(s)
+}
+
+func (p *queueProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *queueProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// queueExporter decrements the queued span count when spans leave the batch processor.
+type queueExporter struct {
+	sdktrace.SpanExporter
+	metrics *pipelineMetrics
+}
+
+func (e *queueExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.metrics.queued.Add(-int64(len(spans)))
+	return e.SpanExporter.ExportSpans(ctx, spans)
+}
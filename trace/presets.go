@@ -0,0 +1,90 @@
+package trace
+
+import (
+	"encoding/base64"
+	"time"
+)
+
+const (
+	honeycombEndpoint       = "api.honeycomb.io:443"
+	honeycombAPIKeyHeader   = "x-honeycomb-team"
+	newRelicEndpoint        = "otlp.nr-data.net:4317"
+	newRelicLicenseHeader   = "api-key"
+	grafanaCloudURLPath     = "/otlp/v1/traces"
+	defaultDatadogAgentHost = "localhost:4317"
+
+	presetBatchTimeout = 5 * time.Second
+	presetMaxBatchSize = 512
+	// SaaS backends reject very large payloads, keep batches smaller than the default
+	saasMaxBatchSize = 256
+)
+
+// PresetHoneycomb returns a configuration exporting to Honeycomb over OTLP/gRPC with TLS.
+// Set AppName (and usually SampleRate) on the returned configuration before initializing.
+func PresetHoneycomb(apiKey string) TracerConfig {
+	return TracerConfig{
+		TracerVendor: "honeycomb",
+		TraceURL:     honeycombEndpoint,
+		TraceEnabled: true,
+		ExporterType: mustExporterType(ExporterTypeGRPC),
+		Headers:      map[string]string{honeycombAPIKeyHeader: apiKey},
+		BatchTimeout: presetBatchTimeout,
+		MaxBatchSize: saasMaxBatchSize,
+	}
+}
+
+// PresetGrafanaCloud returns a configuration exporting to the Grafana Cloud OTLP gateway
+// over HTTP with basic authentication. zone is the stack zone, e.g. "prod-us-east-0".
+func PresetGrafanaCloud(zone, instanceID, token string) TracerConfig {
+	credentials := base64.StdEncoding.EncodeToString([]byte(instanceID + ":" + token))
+	return TracerConfig{
+		TracerVendor: "grafana-cloud",
+		TraceURL:     "otlp-gateway-" + zone + ".grafana.net",
+		URLPath:      grafanaCloudURLPath,
+		TraceEnabled: true,
+		ExporterType: mustExporterType(ExporterTypeHTTP),
+		Headers:      map[string]string{"Authorization": "Basic " + credentials},
+		BatchTimeout: presetBatchTimeout,
+		MaxBatchSize: saasMaxBatchSize,
+	}
+}
+
+// PresetNewRelic returns a configuration exporting to New Relic (US region) over OTLP/gRPC
+// with TLS. For the EU region, set TraceURL to "otlp.eu01.nr-data.net:4317".
+func PresetNewRelic(licenseKey string) TracerConfig {
+	return TracerConfig{
+		TracerVendor: "newrelic",
+		TraceURL:     newRelicEndpoint,
+		TraceEnabled: true,
+		ExporterType: mustExporterType(ExporterTypeGRPC),
+		Headers:      map[string]string{newRelicLicenseHeader: licenseKey},
+		BatchTimeout: presetBatchTimeout,
+		MaxBatchSize: saasMaxBatchSize,
+	}
+}
+
+// PresetDatadogAgent returns a configuration exporting to the OTLP/gRPC ingest of a
+// Datadog agent. An empty agentAddr uses "localhost:4317"; the agent connection is insecure.
+func PresetDatadogAgent(agentAddr string) TracerConfig {
+	if agentAddr == "" {
+		agentAddr = defaultDatadogAgentHost
+	}
+	return TracerConfig{
+		TracerVendor: "datadog",
+		TraceURL:     agentAddr,
+		TraceEnabled: true,
+		Insecure:     true,
+		ExporterType: mustExporterType(ExporterTypeGRPC),
+		BatchTimeout: presetBatchTimeout,
+		MaxBatchSize: presetMaxBatchSize,
+	}
+}
+
+// mustExporterType returns the exporter type for a known constant.
+func mustExporterType(value string) ExporterType {
+	exporterType, err := NewExporterType(value)
+	if err != nil {
+		panic(err)
+	}
+	return exporterType
+}
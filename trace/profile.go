@@ -0,0 +1,70 @@
+package trace
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ProfileLowLatency exports small batches quickly, for interactive debugging and low traffic
+	ProfileLowLatency = "low_latency"
+	// ProfileHighThroughput exports large batches with a deep queue, for busy services
+	ProfileHighThroughput = "high_throughput"
+	// ProfileServerless exports every span synchronously when it ends, so nothing is
+	// lost when the function instance is frozen between invocations
+	ProfileServerless = "serverless"
+)
+
+// Profile is a preset of batch timeout, queue size, export batch size, and processor
+// type. Fields set explicitly in TracerConfig take precedence over the profile.
+type Profile struct {
+	value string
+}
+
+func NewProfile(value string) (Profile, error) {
+	switch value {
+	case ProfileLowLatency, ProfileHighThroughput, ProfileServerless:
+		return Profile{value: value}, nil
+	default:
+		return Profile{}, fmt.Errorf("%w: %s", ErrInvalidProfile, value)
+	}
+}
+
+func (p Profile) String() string {
+	return p.value
+}
+
+func (p Profile) IsZero() bool {
+	return p.value == ""
+}
+
+// apply fills the zero export tuning fields of config with the profile's values.
+func (p Profile) apply(c *TracerConfig) {
+	var (
+		batchTimeout time.Duration
+		maxBatchSize int
+		maxQueueSize int
+	)
+
+	switch p.value {
+	case ProfileLowLatency:
+		batchTimeout, maxBatchSize, maxQueueSize = 200*time.Millisecond, 128, 2048
+	case ProfileHighThroughput:
+		batchTimeout, maxBatchSize, maxQueueSize = 10*time.Second, 2048, 16384
+	case ProfileServerless:
+		c.SyncExport = true
+		return
+	default:
+		return
+	}
+
+	if c.BatchTimeout == 0 {
+		c.BatchTimeout = batchTimeout
+	}
+	if c.MaxBatchSize == 0 {
+		c.MaxBatchSize = maxBatchSize
+	}
+	if c.MaxQueueSize == 0 {
+		c.MaxQueueSize = maxQueueSize
+	}
+}
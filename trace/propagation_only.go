@@ -0,0 +1,45 @@
+package trace
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// InitializePropagationOnly initializes the package without an exporter, for gateways and
+// sidecars that forward trace context but do not produce spans. It installs the W3C trace
+// context and baggage propagators and a no-op tracer provider as the OpenTelemetry globals:
+// spans started by Span and the integrations are not recorded but carry the span context
+// of their parent, so the incoming traceparent is propagated unchanged to outgoing calls.
+//
+// SetSampleRate and SetTracingEnabled return ErrPropagationOnly. Shutdown works as after
+// Initialize.
+func InitializePropagationOnly() error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if initialized {
+		return ErrAlreadyInitialized
+	}
+
+	tp := noop.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(newPropagator())
+
+	globalTracer = tp.Tracer(externalTracerName)
+	globalExternalProvider = tp
+	globalPropagationOnly = true
+	globalSemconvStability = semconvStabilityFromEnv()
+	globalStatementSanitizer = defaultStatementSanitizer
+	initialized = true
+
+	return nil
+}
+
+// samplingControlError is the error of the sampling controls when no sampler is owned by
+// the package.
+func samplingControlError() error {
+	if globalPropagationOnly {
+		return ErrPropagationOnly
+	}
+	return ErrExternalTracerProvider
+}
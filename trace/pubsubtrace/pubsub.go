@@ -0,0 +1,96 @@
+// Package pubsubtrace traces Google Cloud Pub/Sub publishing and receiving through the
+// global tracer configured by the trace package, propagating the trace context in the
+// message attributes.
+package pubsubtrace
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	operationSend    = "send"
+	operationProcess = "process"
+
+	messagingLatencyKey = attribute.Key("messaging.latency_ms")
+)
+
+// Publish publishes msg like topic.Publish within a producer span named "send <topic>",
+// after injecting the trace context into msg.Attributes. The span ends when the publish
+// completes, with the server-assigned message ID or the publish error.
+func Publish(ctx context.Context, topic *pubsub.Topic, msg *pubsub.Message) *pubsub.PublishResult {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemGCPPubSub,
+		semconv.MessagingDestinationName(topic.ID()),
+		semconv.MessagingOperationName(operationSend),
+		semconv.MessagingOperationTypeSend,
+		semconv.MessagingMessageBodySize(len(msg.Data)),
+	}
+	if msg.OrderingKey != "" {
+		attrs = append(attrs, semconv.MessagingGCPPubSubMessageOrderingKey(msg.OrderingKey))
+	}
+
+	ctx, span := trace.Span(ctx, operationSend+" "+topic.ID(),
+		oteltrace.WithSpanKind(oteltrace.SpanKindProducer),
+		oteltrace.WithAttributes(attrs...),
+	)
+
+	if msg.Attributes == nil {
+		msg.Attributes = map[string]string{}
+	}
+	trace.Inject(ctx, msg.Attributes)
+
+	result := topic.Publish(ctx, msg)
+	go func() {
+		defer span.End()
+		<-result.Ready()
+		id, err := result.Get(context.Background())
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		span.SetAttributes(semconv.MessagingMessageID(id))
+	}()
+	return result
+}
+
+// Receive receives messages like sub.Receive and calls f for every message within a
+// consumer span named "process <subscription>". The span continues the trace of the
+// publisher, links to its span and records the ordering key, the delivery attempt and
+// the time spent in the subscription since publishing as messaging.latency_ms.
+func Receive(ctx context.Context, sub *pubsub.Subscription, f func(context.Context, *pubsub.Message)) error {
+	return sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		attrs := []attribute.KeyValue{
+			semconv.MessagingSystemGCPPubSub,
+			semconv.MessagingDestinationSubscriptionName(sub.ID()),
+			semconv.MessagingOperationName(operationProcess),
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingMessageID(msg.ID),
+			semconv.MessagingMessageBodySize(len(msg.Data)),
+		}
+		if msg.OrderingKey != "" {
+			attrs = append(attrs, semconv.MessagingGCPPubSubMessageOrderingKey(msg.OrderingKey))
+		}
+		if msg.DeliveryAttempt != nil {
+			attrs = append(attrs, semconv.MessagingGCPPubSubMessageDeliveryAttempt(*msg.DeliveryAttempt))
+		}
+		if !msg.PublishTime.IsZero() {
+			attrs = append(attrs, messagingLatencyKey.Int64(time.Since(msg.PublishTime).Milliseconds()))
+		}
+
+		ctx, span := trace.ConsumerSpanFromCarrier(ctx, msg.Attributes, operationProcess+" "+sub.ID(),
+			oteltrace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		f(ctx, msg)
+	})
+}
@@ -0,0 +1,90 @@
+// Package reconciletrace traces the reconcile loop of controller-runtime operators through
+// the global tracer configured by the trace package, and correlates the reconciler's logs
+// with its spans.
+package reconciletrace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/cristiano-pacheco/go-otel/trace/logrtrace"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	spanNamePrefix = "reconcile "
+
+	resultSuccess  = "success"
+	resultRequeue  = "requeue"
+	resultError    = "error"
+	resultTerminal = "terminal_error"
+
+	groupKey        = attribute.Key("k8s.object.group")
+	versionKey      = attribute.Key("k8s.object.version")
+	kindKey         = attribute.Key("k8s.object.kind")
+	nameKey         = attribute.Key("k8s.object.name")
+	resultKey       = attribute.Key("reconcile.result")
+	requeueKey      = attribute.Key("reconcile.requeue")
+	requeueAfterKey = attribute.Key("reconcile.requeue_after_ms")
+)
+
+// Reconciler wraps a reconcile.Reconciler and starts a root span for every reconcile.
+type Reconciler struct {
+	reconciler reconcile.Reconciler
+	gvk        schema.GroupVersionKind
+}
+
+// NewReconciler wraps the reconciler of the objects of kind gvk, e.g.
+// appsv1.SchemeGroupVersion.WithKind("Deployment").
+func NewReconciler(reconciler reconcile.Reconciler, gvk schema.GroupVersionKind) *Reconciler {
+	return &Reconciler{reconciler: reconciler, gvk: gvk}
+}
+
+// Reconcile reconciles the request within a root span named "reconcile <Kind>", carrying
+// the GVK, namespace and name of the object, the result and the requeue delay. A reconcile
+// is triggered by a watch rather than by a caller, so the span starts a new trace. The
+// logger of the context, as returned by log.FromContext, gets the trace and span IDs.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := trace.Span(ctx, spanNamePrefix+r.gvk.Kind,
+		oteltrace.WithNewRoot(),
+		oteltrace.WithAttributes(
+			groupKey.String(r.gvk.Group),
+			versionKey.String(r.gvk.Version),
+			kindKey.String(r.gvk.Kind),
+			semconv.K8SNamespaceName(req.Namespace),
+			nameKey.String(req.Name),
+		),
+	)
+	defer span.End()
+
+	ctx = log.IntoContext(ctx, logrtrace.Logger(ctx, log.FromContext(ctx)))
+
+	result, err := r.reconciler.Reconcile(ctx, req)
+
+	//nolint:staticcheck // Requeue is deprecated but still honored by controller-runtime
+	requeue := result.Requeue || result.RequeueAfter > 0
+	span.SetAttributes(requeueKey.Bool(requeue))
+	if result.RequeueAfter > 0 {
+		span.SetAttributes(requeueAfterKey.Int64(result.RequeueAfter.Milliseconds()))
+	}
+
+	switch {
+	case errors.Is(err, reconcile.TerminalError(nil)):
+		span.SetAttributes(resultKey.String(resultTerminal))
+		trace.RecordError(ctx, err)
+	case err != nil:
+		span.SetAttributes(resultKey.String(resultError))
+		trace.RecordError(ctx, err)
+	case requeue:
+		span.SetAttributes(resultKey.String(resultRequeue))
+	default:
+		span.SetAttributes(resultKey.String(resultSuccess))
+	}
+	return result, err
+}
@@ -0,0 +1,189 @@
+package trace
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"net"
+	"reflect"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Error categories recorded as error.type. ErrorTypeTimeout, ErrorTypeCanceled and
+// ErrorTypeNotFound are detected by the built-in classifiers, the others are meant for
+// classifiers registered by the application.
+const (
+	ErrorTypeTimeout    = "timeout"
+	ErrorTypeCanceled   = "canceled"
+	ErrorTypeNotFound   = "not_found"
+	ErrorTypeValidation = "validation"
+	ErrorTypeDownstream = "downstream"
+)
+
+// ErrorClassifier returns the error.type category of err and true, or false when it does
+// not recognize the error.
+type ErrorClassifier func(err error) (string, bool)
+
+const (
+	errorCauseEvent    = "exception.cause"
+	errorChainDepthKey = attribute.Key("exception.cause.depth")
+	maxErrorChainDepth = 16
+)
+
+var (
+	errorClassifiersMutex sync.RWMutex
+	errorClassifiers      []ErrorClassifier
+)
+
+// RegisterErrorClassifier adds a classifier used by RecordError. Classifiers run in
+// registration order before the built-in ones, the first match wins.
+func RegisterErrorClassifier(classifier ErrorClassifier) {
+	errorClassifiersMutex.Lock()
+	defer errorClassifiersMutex.Unlock()
+	errorClassifiers = append(errorClassifiers, classifier)
+}
+
+// RecordError records err on the span in ctx, sets the span status to Error, and sets
+// error.type to the category returned by the classifiers, or to the type of the innermost
+// wrapped error (e.g. *fs.PathError) when none matches. A nil err is ignored.
+func RecordError(ctx context.Context, err error, opts ...oteltrace.EventOption) {
+	if err == nil {
+		return
+	}
+
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.RecordError(err, opts...)
+	span.SetStatus(codes.Error, err.Error())
+	span.SetAttributes(semconv.ErrorTypeKey.String(ClassifyError(err)))
+
+	globalMutex.RLock()
+	recordChain := globalRecordErrorChain
+	globalMutex.RUnlock()
+
+	if recordChain {
+		recordErrorChain(span, err)
+	}
+}
+
+// recordErrorChain adds an exception.cause event for every error wrapped by err, walking
+// both Unwrap() error and Unwrap() []error (errors.Join), depth first.
+func recordErrorChain(span oteltrace.Span, err error) {
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		if depth > maxErrorChainDepth {
+			return
+		}
+		for _, cause := range unwrapErrors(err) {
+			span.AddEvent(errorCauseEvent, oteltrace.WithAttributes(
+				semconv.ExceptionType(reflect.TypeOf(cause).String()),
+				semconv.ExceptionMessage(cause.Error()),
+				errorChainDepthKey.Int(depth),
+			))
+			walk(cause, depth+1)
+		}
+	}
+	walk(err, 1)
+}
+
+func unwrapErrors(err error) []error {
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapped.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	case interface{ Unwrap() []error }:
+		return slices.DeleteFunc(slices.Clone(wrapped.Unwrap()), func(cause error) bool {
+			return cause == nil
+		})
+	}
+	return nil
+}
+
+// ClassifyError returns the error.type value RecordError would record for err.
+func ClassifyError(err error) string {
+	errorClassifiersMutex.RLock()
+	classifiers := errorClassifiers
+	errorClassifiersMutex.RUnlock()
+
+	for _, classify := range classifiers {
+		if category, ok := classify(err); ok {
+			return category
+		}
+	}
+	for _, classify := range builtinErrorClassifiers {
+		if category, ok := classify(err); ok {
+			return category
+		}
+	}
+	return errorTypeName(rootCause(err))
+}
+
+var builtinErrorClassifiers = []ErrorClassifier{
+	classifyTimeout,
+	classifyCanceled,
+	classifyNotFound,
+}
+
+func classifyTimeout(err error) (string, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorTypeTimeout, true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorTypeTimeout, true
+	}
+	return "", false
+}
+
+func classifyCanceled(err error) (string, bool) {
+	return ErrorTypeCanceled, errors.Is(err, context.Canceled)
+}
+
+func classifyNotFound(err error) (string, bool) {
+	return ErrorTypeNotFound, errors.Is(err, fs.ErrNotExist) || errors.Is(err, sql.ErrNoRows)
+}
+
+// rootCause follows the Unwrap chain to the innermost error. For errors wrapping
+// several errors (errors.Join) the first one is followed.
+func rootCause(err error) error {
+	for {
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			next := wrapped.Unwrap()
+			if next == nil {
+				return err
+			}
+			err = next
+		case interface{ Unwrap() []error }:
+			errs := wrapped.Unwrap()
+			if len(errs) == 0 || errs[0] == nil {
+				return err
+			}
+			err = errs[0]
+		default:
+			return err
+		}
+	}
+}
+
+// errorTypeName returns the type of err, e.g. *fs.PathError. Errors created with
+// errors.New or fmt.Errorf have no useful type and are reported as _OTHER.
+func errorTypeName(err error) string {
+	name := reflect.TypeOf(err).String()
+	switch name {
+	case "*errors.errorString", "*fmt.wrapError", "*fmt.wrapErrors", "*errors.joinError":
+		return semconv.ErrorTypeOther.Value.AsString()
+	}
+	return name
+}
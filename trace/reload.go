@@ -0,0 +1,279 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// reloadableFields are the TracerConfig fields Reload applies to the running tracer.
+var reloadableFields = map[string]bool{
+	"SampleRate":         true,
+	"TenantSampleRates":  true,
+	"TenantBaggageKey":   true,
+	"KindSampleRates":    true,
+	"SampleRateSchedule": true,
+	"ConsistentSampling": true,
+	"DebugHeader":        true,
+	"Headers":            true,
+	"GlobalAttributes":   true,
+	"TraceEnabled":       true,
+}
+
+// reloadMutex serializes Reload, which creates exporters without holding globalMutex.
+var reloadMutex sync.Mutex
+
+// reloadState holds what Reload changes on the running tracer besides the sampler.
+type reloadState struct {
+	attributes *globalAttributes
+	exporter   *reloadableExporter // nil when TraceEnabled is false
+}
+
+// Reload applies the runtime-adjustable settings of config to the running tracer: the
+// sampling settings (SampleRate, TenantSampleRates, TenantBaggageKey, KindSampleRates,
+// SampleRateSchedule, ConsistentSampling and DebugHeader), TraceEnabled, Headers and
+// GlobalAttributes. The sampler is rebuilt as by Initialize, replacing a rate set with
+// SetSampleRate, and a SampleRateSchedule keeps counting from Initialize. Changed headers
+// recreate the exporter. Other fields require Shutdown and Initialize: Reload returns
+// ErrReloadUnsupported naming them when they differ from the running configuration, as
+// it does for TraceEnabled when tracing was disabled at Initialize.
+func Reload(config TracerConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	config.setDefaults()
+
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	// a lazy exporter probes the collector, so it is created before locking globalMutex
+	exp, err := reloadExporter(config)
+	if err != nil {
+		return err
+	}
+
+	replaced, err := applyReload(config, exp)
+	if err != nil {
+		replaced = exp
+	}
+	if replaced != nil {
+		shutdownReplacedExporter(replaced, config.ShutdownTimeout)
+	}
+	return err
+}
+
+// reloadExporter returns the exporter for config when Reload changes the headers of the
+// running one, nil when the running exporter stays.
+func reloadExporter(config TracerConfig) (sdktrace.SpanExporter, error) {
+	globalMutex.RLock()
+	changed := initialized && globalReload != nil && globalReload.exporter != nil &&
+		!maps.Equal(config.Headers, globalConfig.Headers)
+	globalMutex.RUnlock()
+
+	if !changed {
+		return nil, nil
+	}
+	return createExporter(config)
+}
+
+// applyReload applies config to the running tracer, swapping in exp when not nil, and
+// returns the exporter it replaced.
+func applyReload(config TracerConfig, exp sdktrace.SpanExporter) (sdktrace.SpanExporter, error) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if !initialized {
+		return nil, ErrNotInitialized
+	}
+	if globalSamplingControl == nil {
+		return nil, samplingControlError()
+	}
+	if fields := unreloadableChanges(globalConfig, config); len(fields) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrReloadUnsupported, strings.Join(fields, ", "))
+	}
+
+	var replaced sdktrace.SpanExporter
+	if exp != nil {
+		replaced = globalReload.exporter.swap(exp)
+	}
+	globalReload.attributes.set(config.GlobalAttributes)
+
+	control := globalSamplingControl
+	control.setConsistent(config.ConsistentSampling)
+	control.override.Store(nil)
+	control.sampler.store(newSampler(config, control))
+	tracingDisabled.Store(!config.TraceEnabled)
+	globalDebugHeader = config.DebugHeader
+
+	// TraceEnabled keeps reporting whether an exporter exists, the switch is in control
+	config.TraceEnabled = globalConfig.TraceEnabled
+	globalConfig = config
+	return replaced, nil
+}
+
+// unreloadableChanges returns the fields other than reloadableFields that differ between
+// the running configuration and next.
+func unreloadableChanges(running, next TracerConfig) []string {
+	var fields []string
+	if !running.TraceEnabled && next.TraceEnabled {
+		fields = append(fields, "TraceEnabled")
+	}
+
+	rv, nv := reflect.ValueOf(running), reflect.ValueOf(next)
+	for i := range rv.NumField() {
+		name := rv.Type().Field(i).Name
+		if !reloadableFields[name] && !fieldEqual(rv.Field(i), nv.Field(i)) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// fieldEqual is reflect.DeepEqual treating nil and empty maps and slices as equal, as
+// configuration decoders produce either.
+func fieldEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Map, reflect.Slice:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// globalAttributes sets TracerConfig.GlobalAttributes on every span as it starts.
+type globalAttributes struct {
+	attrs atomic.Pointer[[]attribute.KeyValue]
+}
+
+var _ sdktrace.SpanProcessor = (*globalAttributes)(nil)
+
+func newGlobalAttributes(attrs map[string]string) *globalAttributes {
+	g := &globalAttributes{}
+	g.set(attrs)
+	return g
+}
+
+func (g *globalAttributes) set(attrs map[string]string) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, key := range slices.Sorted(maps.Keys(attrs)) {
+		kvs = append(kvs, attribute.String(key, attrs[key]))
+	}
+	g.attrs.Store(&kvs)
+}
+
+func (g *globalAttributes) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	if attrs := *g.attrs.Load(); len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+}
+
+func (g *globalAttributes) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (g *globalAttributes) Shutdown(context.Context) error {
+	return nil
+}
+
+func (g *globalAttributes) ForceFlush(context.Context) error {
+	return nil
+}
+
+// reloadableExporter delegates to an exporter Reload can replace while spans are exported.
+type reloadableExporter struct {
+	mu       sync.RWMutex
+	exporter sdktrace.SpanExporter
+}
+
+var _ sdktrace.SpanExporter = (*reloadableExporter)(nil)
+
+func newReloadableExporter(exporter sdktrace.SpanExporter) *reloadableExporter {
+	return &reloadableExporter{exporter: exporter}
+}
+
+// swap replaces the exporter once the running export completes and returns the previous one.
+func (e *reloadableExporter) swap(exporter sdktrace.SpanExporter) sdktrace.SpanExporter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	previous := e.exporter
+	e.exporter = exporter
+	return previous
+}
+
+// shutdownReplacedExporter shuts down an exporter Reload replaced or did not use, logging
+// failures as its spans were already exported.
+func shutdownReplacedExporter(exporter sdktrace.SpanExporter, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := exporter.Shutdown(ctx); err != nil {
+		slog.Default().Warn("Failed to shutdown replaced trace exporter", "error", err)
+	}
+}
+
+func (e *reloadableExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.exporter.ExportSpans(ctx, spans)
+}
+
+func (e *reloadableExporter) Shutdown(ctx context.Context) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.exporter.Shutdown(ctx)
+}
+
+// ReloadOnSignal calls load and applies its result with Reload every time the process
+// receives SIGHUP, so sampling can be changed by editing the configuration source and
+// signaling the process. Failures are logged and keep the current settings.
+// The returned function stops listening.
+func ReloadOnSignal(load func() (TracerConfig, error)) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				reloadFrom(load)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+func reloadFrom(load func() (TracerConfig, error)) {
+	logger := slog.Default()
+
+	config, err := load()
+	if err != nil {
+		logger.Error("Failed to load tracer configuration", "error", err)
+		return
+	}
+	if err := Reload(config); err != nil {
+		logger.Error("Failed to reload tracer configuration", "error", err)
+		return
+	}
+	logger.Info("Tracer configuration reloaded", "sample_rate", config.SampleRate, "enabled", config.TraceEnabled)
+}
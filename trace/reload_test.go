@@ -0,0 +1,73 @@
+package trace
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestUnreloadableChanges(t *testing.T) {
+	running := TracerConfig{
+		AppName:      "orders",
+		TraceEnabled: true,
+		SampleRate:   0.1,
+		Headers:      map[string]string{"api-key": "a"},
+		KafkaBrokers: nil,
+	}
+
+	tests := []struct {
+		name   string
+		change func(*TracerConfig)
+		want   []string
+	}{
+		{
+			name:   "unchanged",
+			change: func(*TracerConfig) {},
+		},
+		{
+			name: "reloadable fields",
+			change: func(c *TracerConfig) {
+				c.SampleRate = 0.5
+				c.Headers = map[string]string{"api-key": "b"}
+				c.GlobalAttributes = map[string]string{"region": "eu"}
+				c.ConsistentSampling = true
+				c.DebugHeader = "X-Debug"
+				c.TraceEnabled = false
+			},
+		},
+		{
+			name:   "empty and nil slices are equal",
+			change: func(c *TracerConfig) { c.KafkaBrokers = []string{} },
+		},
+		{
+			name:   "unreloadable field",
+			change: func(c *TracerConfig) { c.AppName = "billing" },
+			want:   []string{"AppName"},
+		},
+		{
+			name: "several unreloadable fields in declaration order",
+			change: func(c *TracerConfig) {
+				c.ShutdownTimeout = time.Second
+				c.TraceURL = "collector:4317"
+			},
+			want: []string{"TraceURL", "ShutdownTimeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := running
+			tt.change(&next)
+			if got := unreloadableChanges(running, next); !slices.Equal(got, tt.want) {
+				t.Errorf("unreloadableChanges = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnreloadableChangesEnablingTracing(t *testing.T) {
+	got := unreloadableChanges(TracerConfig{}, TracerConfig{TraceEnabled: true})
+	if want := []string{"TraceEnabled"}; !slices.Equal(got, want) {
+		t.Errorf("unreloadableChanges = %v, want %v", got, want)
+	}
+}
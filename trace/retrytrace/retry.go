@@ -0,0 +1,71 @@
+// Package retrytrace runs avast/retry-go retries within a span of the global tracer
+// configured by the trace package, recording every retry as an event.
+package retrytrace
+
+import (
+	"context"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	retryEvent = "retry.attempt"
+
+	attemptKey = attribute.Key("retry.attempt")
+	delayKey   = attribute.Key("retry.delay_ms")
+	countKey   = attribute.Key("retry.count")
+)
+
+// Do runs fn like retry.Do within a span named name, stopping the retries when ctx is done.
+// Every retry adds a retry.attempt event with the attempt number, the delay since the
+// failed attempt and its error. The span gets retry.count, the number of retries, and the
+// final error.
+func Do(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...retry.Option) error {
+	_, err := DoWithData(ctx, name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// DoWithData is like Do for functions returning a value, like retry.DoWithData.
+func DoWithData[T any](
+	ctx context.Context,
+	name string,
+	fn func(ctx context.Context) (T, error),
+	opts ...retry.Option,
+) (T, error) {
+	ctx, span := trace.Span(ctx, name)
+	defer span.End()
+
+	var attempts int
+	var lastErr error
+	var lastFailure time.Time
+
+	value, err := retry.DoWithData(func() (T, error) {
+		attempts++
+		if attempts > 1 {
+			span.AddEvent(retryEvent, oteltrace.WithAttributes(
+				attemptKey.Int(attempts),
+				delayKey.Int64(time.Since(lastFailure).Milliseconds()),
+				semconv.ErrorMessage(lastErr.Error()),
+			))
+		}
+
+		value, err := fn(ctx)
+		if err != nil {
+			lastErr, lastFailure = err, time.Now()
+		}
+		return value, err
+	}, append(opts, retry.Context(ctx))...)
+
+	span.SetAttributes(countKey.Int(max(attempts-1, 0)))
+	if err != nil {
+		trace.RecordError(ctx, err)
+	}
+	return value, err
+}
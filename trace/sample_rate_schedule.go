@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"fmt"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SampleRateStep applies Rate for Duration, see TracerConfig.SampleRateSchedule.
+type SampleRateStep struct {
+	Duration time.Duration
+	Rate     float64 // 0.0 to 1.0
+}
+
+// scheduleSampler applies the rate of the current schedule step, measured from started,
+// and the base sampler once the schedule is over.
+type scheduleSampler struct {
+	steps   []scheduledStep
+	base    sdktrace.Sampler
+	started time.Time
+}
+
+type scheduledStep struct {
+	end     time.Duration // since started
+	rate    float64
+	sampler sdktrace.Sampler
+}
+
+func newScheduleSampler(
+	schedule []SampleRateStep,
+	ratio func(rate float64) sdktrace.Sampler,
+	base sdktrace.Sampler,
+	started time.Time,
+) *scheduleSampler {
+	steps := make([]scheduledStep, 0, len(schedule))
+	var end time.Duration
+	for _, step := range schedule {
+		end += step.Duration
+		steps = append(steps, scheduledStep{end: end, rate: step.Rate, sampler: ratio(step.Rate)})
+	}
+	return &scheduleSampler{steps: steps, base: base, started: started}
+}
+
+// current returns the step in effect, false once the schedule is over.
+func (s *scheduleSampler) current() (scheduledStep, bool) {
+	elapsed := time.Since(s.started)
+	for _, step := range s.steps {
+		if elapsed < step.end {
+			return step, true
+		}
+	}
+	return scheduledStep{}, false
+}
+
+func (s *scheduleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if step, ok := s.current(); ok {
+		return step.sampler.ShouldSample(p)
+	}
+	return s.base.ShouldSample(p)
+}
+
+func (s *scheduleSampler) Description() string {
+	if step, ok := s.current(); ok {
+		return fmt.Sprintf("SampleRateSchedule{until=%s,current=%s}",
+			s.started.Add(step.end).Format(time.RFC3339), step.sampler.Description())
+	}
+	return s.base.Description()
+}
+
+// scheduledSampleRate returns the rate of the schedule step in effect, false once the
+// schedule is over.
+func scheduledSampleRate(config TracerConfig, started time.Time) (float64, bool) {
+	if len(config.SampleRateSchedule) == 0 {
+		return 0, false
+	}
+	step, ok := newScheduleSampler(config.SampleRateSchedule, sdktrace.TraceIDRatioBased, nil, started).current()
+	return step.rate, ok
+}
+
+func validateSampleRateSchedule(schedule []SampleRateStep) error {
+	for i, step := range schedule {
+		if step.Duration <= 0 {
+			return fmt.Errorf("%w: step %d", ErrInvalidSampleRateSchedule, i)
+		}
+		if step.Rate < 0.0 || step.Rate > 1.0 {
+			return fmt.Errorf("%w: schedule step %d", ErrInvalidSampleRate, i)
+		}
+	}
+	return nil
+}
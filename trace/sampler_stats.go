@@ -0,0 +1,96 @@
+package trace
+
+import (
+	"sync/atomic"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SamplingCounts holds the number of sampling decisions per outcome.
+type SamplingCounts struct {
+	Sampled    uint64 // recorded and exported
+	RecordOnly uint64 // recorded but not exported
+	Dropped    uint64 // neither recorded nor exported
+}
+
+// SamplerStats is a snapshot of the sampling decisions made since Initialize,
+// split by root spans (no parent) and child spans.
+type SamplerStats struct {
+	Root  SamplingCounts
+	Child SamplingCounts
+}
+
+// SamplerStatsSnapshot returns the sampling decisions made by the global tracer
+// since it was initialized. Returns zero counts when the tracer is not initialized.
+func SamplerStatsSnapshot() SamplerStats {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if globalSamplerStats == nil {
+		return SamplerStats{}
+	}
+	return globalSamplerStats.snapshot()
+}
+
+type samplingCounters struct {
+	sampled    atomic.Uint64
+	recordOnly atomic.Uint64
+	dropped    atomic.Uint64
+}
+
+func (c *samplingCounters) add(decision sdktrace.SamplingDecision) {
+	switch decision {
+	case sdktrace.RecordAndSample:
+		c.sampled.Add(1)
+	case sdktrace.RecordOnly:
+		c.recordOnly.Add(1)
+	case sdktrace.Drop:
+		c.dropped.Add(1)
+	}
+}
+
+func (c *samplingCounters) snapshot() SamplingCounts {
+	return SamplingCounts{
+		Sampled:    c.sampled.Load(),
+		RecordOnly: c.recordOnly.Load(),
+		Dropped:    c.dropped.Load(),
+	}
+}
+
+// samplerStats counts the decisions of the sampler it wraps.
+type samplerStats struct {
+	root  samplingCounters
+	child samplingCounters
+}
+
+func (s *samplerStats) snapshot() SamplerStats {
+	return SamplerStats{
+		Root:  s.root.snapshot(),
+		Child: s.child.snapshot(),
+	}
+}
+
+// countingSampler is a sampler decorator recording every decision in samplerStats.
+type countingSampler struct {
+	sampler sdktrace.Sampler
+	stats   *samplerStats
+}
+
+func newCountingSampler(sampler sdktrace.Sampler, stats *samplerStats) sdktrace.Sampler {
+	return &countingSampler{sampler: sampler, stats: stats}
+}
+
+func (s *countingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.sampler.ShouldSample(p)
+	if oteltrace.SpanContextFromContext(p.ParentContext).IsValid() {
+		s.stats.child.add(result.Decision)
+	} else {
+		s.stats.root.add(result.Decision)
+	}
+	return result
+}
+
+func (s *countingSampler) Description() string {
+	return s.sampler.Description()
+}
@@ -0,0 +1,274 @@
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// lowTraceID is kept by every sample rate above 0, highTraceID only by a rate of 1.
+	lowTraceID  = oteltrace.TraceID{0x01}
+	highTraceID = oteltrace.TraceID{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+)
+
+func localParent(ctx context.Context, sampled bool) context.Context {
+	var flags oteltrace.TraceFlags
+	if sampled {
+		flags = oteltrace.FlagsSampled
+	}
+	return oteltrace.ContextWithSpanContext(ctx, oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    highTraceID,
+		SpanID:     oteltrace.SpanID{0x01},
+		TraceFlags: flags,
+	}))
+}
+
+func withTenant(t *testing.T, tenant string) context.Context {
+	t.Helper()
+	member, err := baggage.NewMember(defaultTenantBaggageKey, tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bag, err := baggage.New(member)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return baggage.ContextWithBaggage(context.Background(), bag)
+}
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TracerConfig
+		ctx     context.Context
+		kind    oteltrace.SpanKind
+		traceID oteltrace.TraceID
+		want    sdktrace.SamplingDecision
+	}{
+		{
+			name:    "rate 1 samples every trace",
+			config:  TracerConfig{SampleRate: 1},
+			traceID: highTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "rate below the default samples by trace ID",
+			config:  TracerConfig{SampleRate: 0.001},
+			traceID: highTraceID,
+			want:    sdktrace.Drop,
+		},
+		{
+			name:    "rate below the default keeps low trace IDs",
+			config:  TracerConfig{SampleRate: 0.001},
+			traceID: lowTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "plain rate from the default samples every trace",
+			config:  TracerConfig{SampleRate: 0.1},
+			traceID: highTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "consistent rate drops high r-values",
+			config:  TracerConfig{SampleRate: 0.1, ConsistentSampling: true},
+			traceID: highTraceID,
+			want:    sdktrace.Drop,
+		},
+		{
+			name:    "consistent rate keeps low r-values",
+			config:  TracerConfig{SampleRate: 0.1, ConsistentSampling: true},
+			traceID: lowTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "kind rate applies to local roots",
+			config:  TracerConfig{SampleRate: 1, KindSampleRates: map[oteltrace.SpanKind]float64{oteltrace.SpanKindServer: 0}},
+			kind:    oteltrace.SpanKindServer,
+			traceID: lowTraceID,
+			want:    sdktrace.Drop,
+		},
+		{
+			name:    "other kinds use the sample rate",
+			config:  TracerConfig{SampleRate: 1, KindSampleRates: map[oteltrace.SpanKind]float64{oteltrace.SpanKindServer: 0}},
+			kind:    oteltrace.SpanKindInternal,
+			traceID: highTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "children follow their local parent over kind rates",
+			config:  TracerConfig{SampleRate: 1, KindSampleRates: map[oteltrace.SpanKind]float64{oteltrace.SpanKindClient: 0}},
+			ctx:     localParent(context.Background(), true),
+			kind:    oteltrace.SpanKindClient,
+			traceID: highTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name: "tenant rate",
+			config: TracerConfig{
+				SampleRate:        1,
+				TenantSampleRates: map[string]float64{"acme": 0},
+				TenantBaggageKey:  defaultTenantBaggageKey,
+			},
+			ctx:     withTenant(t, "acme"),
+			traceID: lowTraceID,
+			want:    sdktrace.Drop,
+		},
+		{
+			name: "unknown tenant uses the sample rate",
+			config: TracerConfig{
+				SampleRate:        1,
+				TenantSampleRates: map[string]float64{"acme": 0},
+				TenantBaggageKey:  defaultTenantBaggageKey,
+			},
+			ctx:     withTenant(t, "other"),
+			traceID: highTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "forced sampling overrides the rate",
+			config:  TracerConfig{SampleRate: 0.001},
+			ctx:     ForceSample(context.Background()),
+			traceID: highTraceID,
+			want:    sdktrace.RecordAndSample,
+		},
+		{
+			name:    "span metrics record dropped spans",
+			config:  TracerConfig{SampleRate: 0.001, SpanMetrics: true},
+			traceID: highTraceID,
+			want:    sdktrace.RecordOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			sampler := newSampler(tt.config, newSamplingControl(tt.config.ConsistentSampling))
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: ctx,
+				TraceID:       tt.traceID,
+				Name:          "operation",
+				Kind:          tt.kind,
+			})
+			if result.Decision != tt.want {
+				t.Errorf("decision = %v, want %v", result.Decision, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwitchSamplerDisabled(t *testing.T) {
+	tracingDisabled.Store(true)
+	t.Cleanup(func() { tracingDisabled.Store(false) })
+
+	config := TracerConfig{SampleRate: 1}
+	sampler := newSampler(config, newSamplingControl(false))
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: ForceSample(context.Background()),
+		TraceID:       lowTraceID,
+	})
+	if result.Decision != sdktrace.Drop {
+		t.Errorf("decision = %v, want %v", result.Decision, sdktrace.Drop)
+	}
+}
+
+func TestSamplingControlSampleRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		consistent bool
+		config     TracerConfig
+		override   float64 // 0 leaves the configured rate
+		want       float64
+	}{
+		{
+			name:   "plain rate below the default",
+			config: TracerConfig{SampleRate: 0.005},
+			want:   0.005,
+		},
+		{
+			name:   "plain rate from the default",
+			config: TracerConfig{SampleRate: 0.2},
+			want:   1,
+		},
+		{
+			name:       "consistent rate",
+			consistent: true,
+			config:     TracerConfig{SampleRate: 0.2},
+			want:       0.2,
+		},
+		{
+			name:     "override from the default",
+			config:   TracerConfig{SampleRate: 0.005},
+			override: 0.5,
+			want:     1,
+		},
+		{
+			name:       "consistent override",
+			consistent: true,
+			config:     TracerConfig{SampleRate: 0.005},
+			override:   0.5,
+			want:       0.5,
+		},
+		{
+			name: "schedule step",
+			config: TracerConfig{
+				SampleRate:         0.2,
+				SampleRateSchedule: []SampleRateStep{{Rate: 0.5, Duration: time.Hour}},
+			},
+			want: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			control := newSamplingControl(tt.consistent)
+			if tt.override > 0 {
+				control.override.Store(&rateOverride{rate: tt.override, sampler: control.rateSampler(tt.override)})
+			}
+			if got := control.sampleRate(tt.config); got != tt.want {
+				t.Errorf("sampleRate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsistentSamplerPropagatesRValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		probability float64
+		traceID     oteltrace.TraceID
+		want        sdktrace.SamplingDecision
+		wantOT      string
+	}{
+		{name: "always", probability: 1, traceID: highTraceID, want: sdktrace.RecordAndSample, wantOT: "r:0;p:0"},
+		{name: "never", probability: 0, traceID: lowTraceID, want: sdktrace.Drop, wantOT: "r:62"},
+		{name: "half keeps r of 1", probability: 0.5, traceID: oteltrace.TraceID{8: 0x10}, want: sdktrace.RecordAndSample, wantOT: "r:1;p:1"},
+		{name: "half drops r of 0", probability: 0.5, traceID: highTraceID, want: sdktrace.Drop, wantOT: "r:0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newConsistentRootSampler(tt.probability).ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       tt.traceID,
+			})
+			if result.Decision != tt.want {
+				t.Errorf("decision = %v, want %v", result.Decision, tt.want)
+			}
+			if got := result.Tracestate.Get(otTraceStateKey); got != tt.wantOT {
+				t.Errorf("ot tracestate = %q, want %q", got, tt.wantOT)
+			}
+		})
+	}
+}
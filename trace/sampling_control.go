@@ -0,0 +1,246 @@
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// samplingControl holds the sampling settings operators can change at runtime.
+type samplingControl struct {
+	override atomic.Pointer[rateOverride] // nil uses the configured sampler
+	started  time.Time                    // start of SampleRateSchedule
+	ratio    func(rate float64) sdktrace.Sampler
+	sampler  reloadableSampler // built by newSampler, replaced by Reload
+
+	consistent bool // ConsistentSampling
+}
+
+type rateOverride struct {
+	rate    float64
+	sampler sdktrace.Sampler
+}
+
+func newSamplingControl(consistent bool) *samplingControl {
+	c := &samplingControl{started: time.Now()}
+	c.setConsistent(consistent)
+	return c
+}
+
+func (c *samplingControl) setConsistent(consistent bool) {
+	c.consistent = consistent
+	c.ratio = newRatioSampler(consistent)
+}
+
+// sampleRate returns the rate the sampler applies now to spans without a per-tenant or
+// per-kind rate: the SetSampleRate rate, else the current SampleRateSchedule step or
+// SampleRate of config, as effectively applied by rateSampler.
+func (c *samplingControl) sampleRate(config TracerConfig) float64 {
+	if override := c.override.Load(); override != nil {
+		return c.effectiveRate(override.rate)
+	}
+	if rate, ok := scheduledSampleRate(config, c.started); ok {
+		return rate
+	}
+	return c.effectiveRate(config.SampleRate)
+}
+
+// effectiveRate returns the rate rateSampler samples at: plain ratio sampling keeps every
+// trace from defaultSampleRate up, consistent sampling applies the rate as is.
+func (c *samplingControl) effectiveRate(rate float64) float64 {
+	if rate >= defaultSampleRate && !c.consistent {
+		return 1
+	}
+	return rate
+}
+
+// rateSampler returns the sampler applying rate, the same for the configured SampleRate
+// and the SetSampleRate rate.
+func (c *samplingControl) rateSampler(rate float64) sdktrace.Sampler {
+	if c.effectiveRate(rate) == 1 && !c.consistent {
+		return sdktrace.AlwaysSample()
+	}
+	return c.ratio(rate)
+}
+
+// reloadableSampler delegates to the sampler built from the configuration, so that Reload
+// can replace it on the running provider.
+type reloadableSampler struct {
+	current atomic.Pointer[sdktrace.Sampler]
+}
+
+func (s *reloadableSampler) store(sampler sdktrace.Sampler) {
+	s.current.Store(&sampler)
+}
+
+func (s *reloadableSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return (*s.current.Load()).ShouldSample(p)
+}
+
+func (s *reloadableSampler) Description() string {
+	return (*s.current.Load()).Description()
+}
+
+// controlledSampler applies the runtime sample rate in place of the configured base sampler.
+type controlledSampler struct {
+	base    sdktrace.Sampler
+	control *samplingControl
+}
+
+func (s *controlledSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if override := s.control.override.Load(); override != nil {
+		return override.sampler.ShouldSample(p)
+	}
+	return s.base.ShouldSample(p)
+}
+
+func (s *controlledSampler) Description() string {
+	if override := s.control.override.Load(); override != nil {
+		return override.sampler.Description()
+	}
+	return s.base.Description()
+}
+
+// switchSampler drops every span while tracing is disabled at runtime, for spans started
+// through the OpenTracing bridge as well.
+type switchSampler struct {
+	sampler sdktrace.Sampler
+	control *samplingControl
+}
+
+func (s *switchSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if tracingDisabled.Load() {
+		return sdktrace.NeverSample().ShouldSample(p)
+	}
+	return s.sampler.ShouldSample(p)
+}
+
+func (s *switchSampler) Description() string {
+	if tracingDisabled.Load() {
+		return "Disabled{" + s.sampler.Description() + "}"
+	}
+	return s.sampler.Description()
+}
+
+// SetSampleRate changes the sample rate of the running tracer, ending any SampleRateSchedule.
+// Per-tenant rates and force sampling keep applying on top of it. The change lasts until Shutdown.
+func SetSampleRate(rate float64) error {
+	if rate < 0.0 || rate > 1.0 || math.IsNaN(rate) {
+		return ErrInvalidSampleRate
+	}
+
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+	if globalSamplingControl == nil {
+		return samplingControlError()
+	}
+	globalSamplingControl.override.Store(&rateOverride{rate: rate, sampler: globalSamplingControl.rateSampler(rate)})
+	return nil
+}
+
+// SetTracingEnabled is Disable or Enable, failing before Initialize. It has no effect
+// when TraceEnabled was false at Initialize, as no exporter exists then.
+func SetTracingEnabled(enabled bool) error {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+	if globalSamplingControl == nil {
+		return samplingControlError()
+	}
+	tracingDisabled.Store(!enabled)
+	return nil
+}
+
+// adminState is the JSON body returned by AdminHandler.
+type adminState struct {
+	Enabled    bool    `json:"enabled"`
+	SampleRate float64 `json:"sample_rate"`
+	Flushed    bool    `json:"flushed,omitempty"`
+}
+
+// AdminHandler returns an http.Handler that lets operators control the tracer at runtime.
+// GET reports the current state. POST accepts the query or form parameters
+// sample_rate (0.0 to 1.0), enabled (true or false) and flush (true triggers ForceFlush).
+// The handler does no authentication: mount it on an internal admin mux behind the
+// application's own access control.
+func AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			if err := applyAdminRequest(r); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		state, err := currentAdminState()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		state.Flushed = r.Method == http.MethodPost && r.FormValue("flush") == "true"
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(state)
+	})
+}
+
+func applyAdminRequest(r *http.Request) error {
+	if value := r.FormValue("sample_rate"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidSampleRate, value)
+		}
+		if err := SetSampleRate(rate); err != nil {
+			return err
+		}
+	}
+	if value := r.FormValue("enabled"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid enabled value %q: %w", value, err)
+		}
+		if err := SetTracingEnabled(enabled); err != nil {
+			return err
+		}
+	}
+	if r.FormValue("flush") == "true" {
+		return ForceFlush(r.Context())
+	}
+	return nil
+}
+
+func currentAdminState() (adminState, error) {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized {
+		return adminState{}, ErrNotInitialized
+	}
+	if globalSamplingControl == nil {
+		return adminState{}, samplingControlError()
+	}
+
+	return adminState{
+		Enabled:    globalConfig.TraceEnabled && IsEnabled(),
+		SampleRate: globalSamplingControl.sampleRate(globalConfig),
+	}, nil
+}
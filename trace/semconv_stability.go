@@ -0,0 +1,74 @@
+package trace
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	SemconvStabilityStable    = "stable"
+	SemconvStabilityLegacy    = "legacy"
+	SemconvStabilityDuplicate = "dup"
+
+	semconvStabilityEnv = "OTEL_SEMCONV_STABILITY_OPT_IN"
+)
+
+// SemconvStability selects which HTTP semantic convention attributes the built-in
+// instrumentation emits: the stable set (http.request.method, url.full, ...), the legacy
+// set (http.method, http.url, ...) or both, mirroring OTEL_SEMCONV_STABILITY_OPT_IN.
+type SemconvStability struct {
+	value string
+}
+
+func NewSemconvStability(value string) (SemconvStability, error) {
+	switch value {
+	case SemconvStabilityStable, SemconvStabilityLegacy, SemconvStabilityDuplicate:
+		return SemconvStability{value: value}, nil
+	default:
+		return SemconvStability{}, fmt.Errorf("%w: %s", ErrInvalidSemconvStability, value)
+	}
+}
+
+// semconvStabilityFromEnv maps OTEL_SEMCONV_STABILITY_OPT_IN, a comma-separated list, to
+// a SemconvStability: "http/dup" selects both sets and takes precedence over "http", which
+// selects the stable set. Anything else selects stable, which is what this package has
+// always emitted.
+func semconvStabilityFromEnv() SemconvStability {
+	for entry := range strings.SplitSeq(os.Getenv(semconvStabilityEnv), ",") {
+		if strings.TrimSpace(entry) == "http/dup" {
+			return SemconvStability{value: SemconvStabilityDuplicate}
+		}
+	}
+	return SemconvStability{value: SemconvStabilityStable}
+}
+
+// HTTPSemconvStability returns the HTTP semantic convention set selected at Initialize.
+// Returns the stable set when the tracer is not initialized.
+func HTTPSemconvStability() SemconvStability {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if globalSemconvStability.IsZero() {
+		return SemconvStability{value: SemconvStabilityStable}
+	}
+	return globalSemconvStability
+}
+
+func (s SemconvStability) String() string {
+	return s.value
+}
+
+// EmitStable reports whether the stable attributes should be emitted.
+func (s SemconvStability) EmitStable() bool {
+	return s.value != SemconvStabilityLegacy
+}
+
+// EmitLegacy reports whether the legacy attributes should be emitted.
+func (s SemconvStability) EmitLegacy() bool {
+	return s.value == SemconvStabilityLegacy || s.value == SemconvStabilityDuplicate
+}
+
+func (s SemconvStability) IsZero() bool {
+	return s.value == ""
+}
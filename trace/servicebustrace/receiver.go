@@ -0,0 +1,198 @@
+package servicebustrace
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	operationReceive    = "receive"
+	operationProcess    = "process"
+	operationComplete   = "complete"
+	operationAbandon    = "abandon"
+	operationDeadLetter = "dead_letter"
+	operationDefer      = "defer"
+
+	messagingLatencyKey = attribute.Key("messaging.latency_ms")
+)
+
+// Receiver wraps an *azservicebus.Receiver and traces receiving, processing and settling
+// messages.
+type Receiver struct {
+	receiver *azservicebus.Receiver
+	entity   string
+}
+
+// NewReceiver wraps the receiver of the queue or subscription named entity, which the
+// azservicebus receiver does not expose.
+func NewReceiver(receiver *azservicebus.Receiver, entity string) *Receiver {
+	return &Receiver{receiver: receiver, entity: entity}
+}
+
+// Unwrap returns the underlying receiver.
+func (r *Receiver) Unwrap() *azservicebus.Receiver {
+	return r.receiver
+}
+
+// ReceiveMessages receives messages like azservicebus.Receiver.ReceiveMessages within a
+// consumer span named "receive <entity>", linked to the producer span of every message.
+func (r *Receiver) ReceiveMessages(
+	ctx context.Context,
+	maxMessages int,
+	options *azservicebus.ReceiveMessagesOptions,
+) ([]*azservicebus.ReceivedMessage, error) {
+	ctx, span := r.startSpan(ctx, operationReceive, oteltrace.SpanKindConsumer, semconv.MessagingOperationTypeReceive)
+	defer span.End()
+
+	messages, err := r.receiver.ReceiveMessages(ctx, maxMessages, options)
+	if err != nil {
+		trace.RecordError(ctx, err)
+		return messages, err
+	}
+
+	span.SetAttributes(semconv.MessagingBatchMessageCount(len(messages)))
+	for _, message := range messages {
+		producer := oteltrace.SpanContextFromContext(extract(context.Background(), message))
+		if producer.IsValid() {
+			span.AddLink(oteltrace.Link{SpanContext: producer})
+		}
+	}
+	return messages, nil
+}
+
+// Process runs fn for message within a consumer span named "process <entity>" continuing
+// the trace of the producer and linked to its span. The span records the delivery count
+// and the time the message spent in the entity as messaging.latency_ms, and the error of fn.
+// Settle the message with the context passed to fn so that settlement spans nest under it.
+func (r *Receiver) Process(
+	ctx context.Context,
+	message *azservicebus.ReceivedMessage,
+	fn func(ctx context.Context) error,
+) error {
+	ctx = extract(ctx, message)
+
+	attrs := []attribute.KeyValue{
+		semconv.MessagingMessageID(message.MessageID),
+		semconv.MessagingMessageBodySize(len(message.Body)),
+		semconv.MessagingServiceBusMessageDeliveryCount(int(message.DeliveryCount)),
+	}
+	if message.CorrelationID != nil {
+		attrs = append(attrs, semconv.MessagingMessageConversationID(*message.CorrelationID))
+	}
+	if message.EnqueuedTime != nil {
+		attrs = append(attrs,
+			semconv.MessagingServiceBusMessageEnqueuedTime(int(message.EnqueuedTime.Unix())),
+			messagingLatencyKey.Int64(time.Since(*message.EnqueuedTime).Milliseconds()),
+		)
+	}
+
+	opts := []oteltrace.SpanStartOption{oteltrace.WithAttributes(attrs...)}
+	if producer := oteltrace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, oteltrace.WithLinks(oteltrace.Link{SpanContext: producer}))
+	}
+	ctx, span := r.startSpan(ctx, operationProcess, oteltrace.SpanKindConsumer, semconv.MessagingOperationTypeProcess, opts...)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		trace.RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// CompleteMessage completes message like azservicebus.Receiver.CompleteMessage within a
+// settlement span.
+func (r *Receiver) CompleteMessage(
+	ctx context.Context,
+	message *azservicebus.ReceivedMessage,
+	options *azservicebus.CompleteMessageOptions,
+) error {
+	return r.settle(ctx, operationComplete, semconv.MessagingServiceBusDispositionStatusComplete, message,
+		func(ctx context.Context) error { return r.receiver.CompleteMessage(ctx, message, options) })
+}
+
+// AbandonMessage abandons message like azservicebus.Receiver.AbandonMessage within a
+// settlement span.
+func (r *Receiver) AbandonMessage(
+	ctx context.Context,
+	message *azservicebus.ReceivedMessage,
+	options *azservicebus.AbandonMessageOptions,
+) error {
+	return r.settle(ctx, operationAbandon, semconv.MessagingServiceBusDispositionStatusAbandon, message,
+		func(ctx context.Context) error { return r.receiver.AbandonMessage(ctx, message, options) })
+}
+
+// DeadLetterMessage dead-letters message like azservicebus.Receiver.DeadLetterMessage
+// within a settlement span.
+func (r *Receiver) DeadLetterMessage(
+	ctx context.Context,
+	message *azservicebus.ReceivedMessage,
+	options *azservicebus.DeadLetterOptions,
+) error {
+	return r.settle(ctx, operationDeadLetter, semconv.MessagingServiceBusDispositionStatusDeadLetter, message,
+		func(ctx context.Context) error { return r.receiver.DeadLetterMessage(ctx, message, options) })
+}
+
+// DeferMessage defers message like azservicebus.Receiver.DeferMessage within a settlement
+// span.
+func (r *Receiver) DeferMessage(
+	ctx context.Context,
+	message *azservicebus.ReceivedMessage,
+	options *azservicebus.DeferMessageOptions,
+) error {
+	return r.settle(ctx, operationDefer, semconv.MessagingServiceBusDispositionStatusDefer, message,
+		func(ctx context.Context) error { return r.receiver.DeferMessage(ctx, message, options) })
+}
+
+// settle runs fn within a client span named "<operation> <entity>".
+func (r *Receiver) settle(
+	ctx context.Context,
+	operation string,
+	disposition attribute.KeyValue,
+	message *azservicebus.ReceivedMessage,
+	fn func(ctx context.Context) error,
+) error {
+	ctx, span := r.startSpan(ctx, operation, oteltrace.SpanKindClient, semconv.MessagingOperationTypeSettle,
+		oteltrace.WithAttributes(disposition, semconv.MessagingMessageID(message.MessageID)),
+	)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		trace.RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (r *Receiver) startSpan(
+	ctx context.Context,
+	operation string,
+	kind oteltrace.SpanKind,
+	operationType attribute.KeyValue,
+	opts ...oteltrace.SpanStartOption,
+) (context.Context, oteltrace.Span) {
+	opts = append([]oteltrace.SpanStartOption{
+		oteltrace.WithSpanKind(kind),
+		oteltrace.WithAttributes(
+			semconv.MessagingSystemServiceBus,
+			semconv.MessagingDestinationName(r.entity),
+			semconv.MessagingOperationName(operation),
+			operationType,
+		),
+	}, opts...)
+	return trace.Span(ctx, operation+" "+r.entity, opts...)
+}
+
+// extract returns ctx with the trace context read from the application properties of message.
+func extract(ctx context.Context, message *azservicebus.ReceivedMessage) context.Context {
+	if message.ApplicationProperties == nil {
+		return ctx
+	}
+	return trace.ExtractCarrier(ctx, trace.AMQPTableCarrier(message.ApplicationProperties))
+}
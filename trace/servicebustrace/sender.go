@@ -0,0 +1,126 @@
+// Package servicebustrace traces Azure Service Bus senders and receivers (azservicebus)
+// through the global tracer configured by the trace package, propagating the trace context
+// in the application properties of the messages.
+package servicebustrace
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	operationCreate = "create"
+	operationSend   = "send"
+)
+
+// Sender wraps an *azservicebus.Sender and traces the messages it sends.
+type Sender struct {
+	sender *azservicebus.Sender
+	entity string
+}
+
+// NewSender wraps the sender of the queue or topic named entity, which the azservicebus
+// sender does not expose.
+func NewSender(sender *azservicebus.Sender, entity string) *Sender {
+	return &Sender{sender: sender, entity: entity}
+}
+
+// Unwrap returns the underlying sender.
+func (s *Sender) Unwrap() *azservicebus.Sender {
+	return s.sender
+}
+
+// SendMessage sends message like azservicebus.Sender.SendMessage within a producer span
+// named "send <entity>", after injecting the trace context into its application properties.
+func (s *Sender) SendMessage(ctx context.Context, message *azservicebus.Message, options *azservicebus.SendMessageOptions) error {
+	ctx, span := s.startSpan(ctx, operationSend, semconv.MessagingOperationTypeSend, messageAttributes(message)...)
+	defer span.End()
+
+	Inject(ctx, message)
+	if err := s.sender.SendMessage(ctx, message, options); err != nil {
+		trace.RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// AddMessage adds message to batch within a producer span named "create <entity>", whose
+// context is injected into the message, so that consumers link to the message rather than
+// to the batch.
+func (s *Sender) AddMessage(
+	ctx context.Context,
+	batch *azservicebus.MessageBatch,
+	message *azservicebus.Message,
+	options *azservicebus.AddMessageOptions,
+) error {
+	ctx, span := s.startSpan(ctx, operationCreate, semconv.MessagingOperationTypeCreate, messageAttributes(message)...)
+	defer span.End()
+
+	Inject(ctx, message)
+	if err := batch.AddMessage(message, options); err != nil {
+		trace.RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// SendMessageBatch sends batch like azservicebus.Sender.SendMessageBatch within a producer
+// span named "send <entity>" recording the number of messages.
+func (s *Sender) SendMessageBatch(
+	ctx context.Context,
+	batch *azservicebus.MessageBatch,
+	options *azservicebus.SendMessageBatchOptions,
+) error {
+	ctx, span := s.startSpan(ctx, operationSend, semconv.MessagingOperationTypeSend,
+		semconv.MessagingBatchMessageCount(int(batch.NumMessages())),
+	)
+	defer span.End()
+
+	if err := s.sender.SendMessageBatch(ctx, batch, options); err != nil {
+		trace.RecordError(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (s *Sender) startSpan(
+	ctx context.Context,
+	operation string,
+	operationType attribute.KeyValue,
+	attrs ...attribute.KeyValue,
+) (context.Context, oteltrace.Span) {
+	return trace.Span(ctx, operation+" "+s.entity,
+		oteltrace.WithSpanKind(oteltrace.SpanKindProducer),
+		oteltrace.WithAttributes(append([]attribute.KeyValue{
+			semconv.MessagingSystemServiceBus,
+			semconv.MessagingDestinationName(s.entity),
+			semconv.MessagingOperationName(operation),
+			operationType,
+		}, attrs...)...),
+	)
+}
+
+// Inject writes the trace context of ctx into the application properties of message, for
+// messages sent without a Sender.
+func Inject(ctx context.Context, message *azservicebus.Message) {
+	if message.ApplicationProperties == nil {
+		message.ApplicationProperties = map[string]any{}
+	}
+	trace.InjectCarrier(ctx, trace.AMQPTableCarrier(message.ApplicationProperties))
+}
+
+func messageAttributes(message *azservicebus.Message) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.MessagingMessageBodySize(len(message.Body))}
+	if message.MessageID != nil {
+		attrs = append(attrs, semconv.MessagingMessageID(*message.MessageID))
+	}
+	if message.CorrelationID != nil {
+		attrs = append(attrs, semconv.MessagingMessageConversationID(*message.CorrelationID))
+	}
+	return attrs
+}
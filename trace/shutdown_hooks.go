@@ -0,0 +1,57 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ShutdownHook is called by Shutdown, see OnShutdown.
+type ShutdownHook func(ctx context.Context) error
+
+var globalShutdownHooks = &shutdownHooks{}
+
+// OnShutdown registers cleanup run by Shutdown in registration order, before the tracer
+// provider shuts down, e.g. to flush a custom processor or stop a metrics pipeline. Hooks
+// stay registered across Shutdown and Initialize, all of them run even when one fails, and
+// they may still create spans. The returned function removes the hook.
+func OnShutdown(hook ShutdownHook) (unregister func()) {
+	return globalShutdownHooks.add(hook)
+}
+
+type shutdownHooks struct {
+	mu     sync.Mutex
+	nextID int
+	hooks  []registeredHook[ShutdownHook]
+}
+
+func (h *shutdownHooks) add(hook ShutdownHook) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	h.hooks = append(slices.Clip(h.hooks), registeredHook[ShutdownHook]{id: id, hook: hook})
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.hooks = withoutHook(h.hooks, id)
+	}
+}
+
+func (h *shutdownHooks) run(ctx context.Context) error {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.mu.Unlock()
+
+	var errs []error
+	for _, registered := range hooks {
+		if err := registered.hook(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrShutdownHook, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package trace
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// SlowSpanDefaultName keys the threshold applied to spans without their own entry
+	SlowSpanDefaultName = "*"
+
+	slowKey = attribute.Key("slow")
+)
+
+// slowSpanProcessor flags spans lasting longer than their threshold with slow=true before
+// passing them to the next processor. Ended spans are read-only, so the attribute is added
+// to the exported view of the span.
+type slowSpanProcessor struct {
+	next       sdktrace.SpanProcessor
+	thresholds map[string]time.Duration
+}
+
+var _ sdktrace.SpanProcessor = (*slowSpanProcessor)(nil)
+
+func newSlowSpanProcessor(next sdktrace.SpanProcessor, thresholds map[string]time.Duration) *slowSpanProcessor {
+	return &slowSpanProcessor{next: next, thresholds: thresholds}
+}
+
+func (p *slowSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *slowSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	threshold, ok := p.thresholds[s.Name()]
+	if !ok {
+		threshold, ok = p.thresholds[SlowSpanDefaultName]
+	}
+	if ok && threshold > 0 && s.EndTime().Sub(s.StartTime()) > threshold {
+		s = slowSpan{ReadOnlySpan: s}
+	}
+	p.next.OnEnd(s)
+}
+
+func (p *slowSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *slowSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// slowSpan adds the slow=true attribute to the wrapped span.
+type slowSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s slowSpan) Attributes() []attribute.KeyValue {
+	return append(slices.Clip(s.ReadOnlySpan.Attributes()), slowKey.Bool(true))
+}
@@ -0,0 +1,100 @@
+package trace
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cristiano-pacheco/go-otel/metric"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	spanMetricsCallsName    = "traces.span.metrics.calls"
+	spanMetricsDurationName = "traces.span.metrics.duration"
+
+	spanNameKey   = attribute.Key("span.name")
+	spanKindKey   = attribute.Key("span.kind")
+	statusCodeKey = attribute.Key("status.code")
+)
+
+// spanMetricsProcessor derives request rate, error rate and duration (RED) metrics from
+// ended spans, grouped by span name, kind and status code, and records them through the
+// metric package. Instruments are created lazily from metric.Meter(), so the metric
+// package may be initialized after the tracer.
+type spanMetricsProcessor struct {
+	mu       sync.Mutex
+	meter    otelmetric.Meter
+	calls    otelmetric.Int64Counter
+	duration otelmetric.Float64Histogram
+}
+
+var _ sdktrace.SpanProcessor = (*spanMetricsProcessor)(nil)
+
+func newSpanMetricsProcessor() *spanMetricsProcessor {
+	return &spanMetricsProcessor{}
+}
+
+func (p *spanMetricsProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *spanMetricsProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	calls, duration, ok := p.instruments()
+	if !ok {
+		return
+	}
+
+	attrs := otelmetric.WithAttributeSet(attribute.NewSet(
+		spanNameKey.String(s.Name()),
+		spanKindKey.String(s.SpanKind().String()),
+		statusCodeKey.String(s.Status().Code.String()),
+	))
+
+	ctx := context.Background()
+	calls.Add(ctx, 1, attrs)
+	duration.Record(ctx, s.EndTime().Sub(s.StartTime()).Seconds(), attrs)
+}
+
+// instruments returns the instruments of the current global meter, recreating them
+// when the metric package was (re)initialized.
+func (p *spanMetricsProcessor) instruments() (otelmetric.Int64Counter, otelmetric.Float64Histogram, bool) {
+	meter := metric.Meter()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if meter == p.meter {
+		return p.calls, p.duration, p.calls != nil
+	}
+
+	calls, err := meter.Int64Counter(
+		spanMetricsCallsName,
+		otelmetric.WithDescription("Number of ended spans"),
+		otelmetric.WithUnit("{span}"),
+	)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	duration, err := meter.Float64Histogram(
+		spanMetricsDurationName,
+		otelmetric.WithDescription("Duration of ended spans"),
+		otelmetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	p.meter = meter
+	p.calls = calls
+	p.duration = duration
+	return calls, duration, true
+}
+
+func (p *spanMetricsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *spanMetricsProcessor) ForceFlush(context.Context) error {
+	return nil
+}
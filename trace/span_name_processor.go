@@ -0,0 +1,88 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanNameRule rewrites the parts of a span name matching Pattern with Replacement.
+// Replacement supports regexp expansion ($1, ${name}).
+type SpanNameRule struct {
+	Pattern     string
+	Replacement string
+}
+
+// DefaultSpanNameRules returns rules replacing UUIDs and numeric path/ID segments
+// with placeholders, e.g. "GET /users/42" becomes "GET /users/{id}".
+func DefaultSpanNameRules() []SpanNameRule {
+	return []SpanNameRule{
+		{
+			Pattern:     `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+			Replacement: "{uuid}",
+		},
+		{
+			Pattern:     `\b\d+\b`,
+			Replacement: "{id}",
+		},
+	}
+}
+
+type compiledSpanNameRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// SpanNameProcessor is a span processor that rewrites span names at start time
+// to keep span name cardinality low. Rules are applied in order.
+type SpanNameProcessor struct {
+	rules []compiledSpanNameRule
+}
+
+var _ sdktrace.SpanProcessor = (*SpanNameProcessor)(nil)
+
+// NewSpanNameProcessor creates a span name processor from the given rules.
+// Returns an error if a rule pattern is not a valid regular expression.
+func NewSpanNameProcessor(rules []SpanNameRule) (*SpanNameProcessor, error) {
+	compiled := make([]compiledSpanNameRule, 0, len(rules))
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidSpanNameRule, rule.Pattern, err)
+		}
+		compiled = append(compiled, compiledSpanNameRule{pattern: pattern, replacement: rule.Replacement})
+	}
+	return &SpanNameProcessor{rules: compiled}, nil
+}
+
+// OnStart rewrites the span name using the configured rules.
+func (p *SpanNameProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	name := s.Name()
+	sanitized := p.Sanitize(name)
+	if sanitized != name {
+		s.SetName(sanitized)
+	}
+}
+
+// Sanitize applies the rules to the given name.
+func (p *SpanNameProcessor) Sanitize(name string) string {
+	for _, rule := range p.rules {
+		name = rule.pattern.ReplaceAllString(name, rule.replacement)
+	}
+	return name
+}
+
+// OnEnd is a no-op.
+func (p *SpanNameProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown is a no-op.
+func (p *SpanNameProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush is a no-op.
+func (p *SpanNameProcessor) ForceFlush(context.Context) error {
+	return nil
+}
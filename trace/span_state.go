@@ -0,0 +1,23 @@
+package trace
+
+import (
+	"context"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// IsSampled reports whether the span in ctx was sampled by the head sampler, so that
+// telemetry-only work, e.g. serializing a payload for an event, can be skipped otherwise.
+// With ErrorBiasedSampling or LatencyThreshold, unsampled spans may still be exported when
+// their trace ends with an error or too late: use IsRecording to decide whether to add data
+// to them.
+func IsSampled(ctx context.Context) bool {
+	return oteltrace.SpanContextFromContext(ctx).IsSampled()
+}
+
+// IsRecording reports whether the span in ctx records attributes and events. It is false
+// without a span, before Initialize, while tracing is disabled and for spans dropped by the
+// sampler, in which case anything added to the span is discarded.
+func IsRecording(ctx context.Context) bool {
+	return oteltrace.SpanFromContext(ctx).IsRecording()
+}
@@ -0,0 +1,173 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const maxStackDepth = 32
+
+// OpenSpan describes a span created via Span that has not been ended.
+type OpenSpan struct {
+	Name      string
+	TraceID   oteltrace.TraceID
+	SpanID    oteltrace.SpanID
+	StartTime time.Time
+	Stack     string // stack of the goroutine that started the span
+}
+
+// TrackSpans starts tracking spans created via Span and returns a function that
+// stops tracking and reports the spans started since the call that are still open.
+// Tracking is global: spans started concurrently by unrelated code are reported too.
+func TrackSpans() func() []OpenSpan {
+	globalMutex.Lock()
+	tracker := acquireSpanTracker()
+	baseline := tracker.seq.Load()
+	globalMutex.Unlock()
+
+	var once sync.Once
+	return func() []OpenSpan {
+		var open []OpenSpan
+		once.Do(func() {
+			for _, span := range tracker.openSpans() {
+				if span.seq > baseline {
+					open = append(open, span.openSpan())
+				}
+			}
+
+			globalMutex.Lock()
+			releaseSpanTracker()
+			globalMutex.Unlock()
+		})
+		return open
+	}
+}
+
+// acquireSpanTracker returns the global span tracker, creating it for the first user.
+// Must be called with globalMutex held.
+func acquireSpanTracker() *spanTracker {
+	if globalSpanTracker == nil {
+		globalSpanTracker = newSpanTracker()
+	}
+	spanTrackerUsers++
+	return globalSpanTracker
+}
+
+// releaseSpanTracker drops the global span tracker once its last user is gone.
+// Must be called with globalMutex held.
+func releaseSpanTracker() {
+	spanTrackerUsers--
+	if spanTrackerUsers <= 0 {
+		spanTrackerUsers = 0
+		globalSpanTracker = nil
+	}
+}
+
+// spanTracker keeps track of spans created through Span that have not been ended yet.
+type spanTracker struct {
+	mu    sync.Mutex
+	spans map[*trackedSpan]struct{}
+	seq   atomic.Uint64
+}
+
+func newSpanTracker() *spanTracker {
+	return &spanTracker{spans: make(map[*trackedSpan]struct{})}
+}
+
+// track wraps the span so that ending it removes it from the tracker. The wrapper
+// replaces the span in the returned context so SpanFromContext(ctx).End() is tracked too.
+func (t *spanTracker) track(
+	ctx context.Context,
+	name string,
+	span oteltrace.Span,
+) (context.Context, oteltrace.Span) {
+	pcs := make([]uintptr, maxStackDepth)
+	// skip runtime.Callers, track and Span
+	n := runtime.Callers(3, pcs)
+
+	tracked := &trackedSpan{
+		Span:    span,
+		tracker: t,
+		seq:     t.seq.Add(1),
+		name:    name,
+		started: time.Now(),
+		stack:   pcs[:n],
+	}
+
+	t.mu.Lock()
+	t.spans[tracked] = struct{}{}
+	t.mu.Unlock()
+
+	return oteltrace.ContextWithSpan(ctx, tracked), tracked
+}
+
+func (t *spanTracker) remove(span *trackedSpan) {
+	t.mu.Lock()
+	delete(t.spans, span)
+	t.mu.Unlock()
+}
+
+// openSpans returns the spans that are still open.
+func (t *spanTracker) openSpans() []*trackedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	spans := make([]*trackedSpan, 0, len(t.spans))
+	for span := range t.spans {
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// trackedSpan is a span wrapper that records where it was created and notifies
+// its tracker when it ends.
+type trackedSpan struct {
+	oteltrace.Span
+
+	tracker *spanTracker
+	seq     uint64
+	name    string
+	started time.Time
+	stack   []uintptr
+	ended   atomic.Bool
+	warned  atomic.Bool
+}
+
+// End removes the span from the tracker and ends the wrapped span.
+func (s *trackedSpan) End(options ...oteltrace.SpanEndOption) {
+	if s.ended.CompareAndSwap(false, true) {
+		s.tracker.remove(s)
+	}
+	s.Span.End(options...)
+}
+
+func (s *trackedSpan) openSpan() OpenSpan {
+	return OpenSpan{
+		Name:      s.name,
+		TraceID:   s.SpanContext().TraceID(),
+		SpanID:    s.SpanContext().SpanID(),
+		StartTime: s.started,
+		Stack:     s.creationStack(),
+	}
+}
+
+// creationStack formats the stack captured when the span was started.
+func (s *trackedSpan) creationStack() string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(s.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
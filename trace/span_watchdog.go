@@ -0,0 +1,74 @@
+package trace
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// spanWatchdog periodically logs a warning for spans that were started more than
+// timeout ago and never ended. Each leaked span is reported once.
+type spanWatchdog struct {
+	tracker *spanTracker
+	timeout time.Duration
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newSpanWatchdog(tracker *spanTracker, timeout time.Duration) *spanWatchdog {
+	return &spanWatchdog{
+		tracker: tracker,
+		timeout: timeout,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// start runs the watchdog loop in a new goroutine.
+func (w *spanWatchdog) start() {
+	go w.run()
+}
+
+// shutdown stops the watchdog loop and waits for it to exit.
+func (w *spanWatchdog) shutdown() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *spanWatchdog) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.checkInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			w.check(now)
+		}
+	}
+}
+
+// checkInterval checks twice per timeout so spans are reported at most timeout/2 late.
+func (w *spanWatchdog) checkInterval() time.Duration {
+	return max(w.timeout/2, time.Millisecond)
+}
+
+func (w *spanWatchdog) check(now time.Time) {
+	logger := slog.Default()
+	for _, span := range w.tracker.openSpans() {
+		age := now.Sub(span.started)
+		if age < w.timeout || !span.warned.CompareAndSwap(false, true) {
+			continue
+		}
+		logger.WarnContext(context.Background(), "Span not ended",
+			"span_name", span.name,
+			"trace_id", span.SpanContext().TraceID().String(),
+			"span_id", span.SpanContext().SpanID().String(),
+			"age", age.String(),
+			"stack", span.creationStack(),
+		)
+	}
+}
@@ -0,0 +1,215 @@
+// Package sqlxtrace wraps sqlx.DB and sqlx.Tx so that queries emit client spans with db.*
+// attributes through the global tracer configured by the trace package, keeping the sqlx
+// API: named queries, Get and Select.
+package sqlxtrace
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSpanName = "db"
+
+	operationCommit   = "COMMIT"
+	operationRollback = "ROLLBACK"
+)
+
+// driverSystems maps common driver names to db.system.name values.
+var driverSystems = map[string]string{
+	"postgres":  "postgresql",
+	"pgx":       "postgresql",
+	"mysql":     "mysql",
+	"sqlite3":   "sqlite",
+	"sqlite":    "sqlite",
+	"sqlserver": "microsoft.sql_server",
+	"mssql":     "microsoft.sql_server",
+	"oracle":    "oracle.db",
+	"godror":    "oracle.db",
+}
+
+// Config configures the recorded attributes.
+type Config struct {
+	// System is db.system.name, by default derived from the driver name, e.g. "postgresql"
+	// for "pgx"
+	System string
+	// Name is db.namespace, the database name
+	Name string
+}
+
+// DB wraps a *sqlx.DB. The context methods listed below are traced, the other methods of
+// the embedded DB are not. Rows returned by QueryxContext and NamedQueryContext are not
+// part of the span, which ends once the query returns.
+type DB struct {
+	*sqlx.DB
+	config Config
+}
+
+// NewDB wraps db.
+func NewDB(db *sqlx.DB, config Config) *DB {
+	if config.System == "" {
+		config.System = driverSystem(db.DriverName())
+	}
+	return &DB{DB: db, config: config}
+}
+
+// Unwrap returns the underlying DB.
+func (db *DB) Unwrap() *sqlx.DB {
+	return db.DB
+}
+
+// GetContext runs query like sqlx.DB.GetContext within a span. sql.ErrNoRows is not
+// recorded as an error.
+func (db *DB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return get(ctx, db.config, query, func(ctx context.Context) error {
+		return db.DB.GetContext(ctx, dest, query, args...)
+	})
+}
+
+// SelectContext runs query like sqlx.DB.SelectContext within a span recording the number
+// of returned rows.
+func (db *DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return selectRows(ctx, db.config, dest, query, func(ctx context.Context) error {
+		return db.DB.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+// ExecContext runs query like sql.DB.ExecContext within a span.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return run(ctx, db.config, query, func(ctx context.Context) (sql.Result, error) {
+		return db.DB.ExecContext(ctx, query, args...)
+	})
+}
+
+// NamedExecContext runs the named query like sqlx.DB.NamedExecContext within a span.
+func (db *DB) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
+	return run(ctx, db.config, query, func(ctx context.Context) (sql.Result, error) {
+		return db.DB.NamedExecContext(ctx, query, arg)
+	})
+}
+
+// NamedQueryContext runs the named query like sqlx.DB.NamedQueryContext within a span.
+func (db *DB) NamedQueryContext(ctx context.Context, query string, arg any) (*sqlx.Rows, error) {
+	return run(ctx, db.config, query, func(ctx context.Context) (*sqlx.Rows, error) {
+		return db.DB.NamedQueryContext(ctx, query, arg)
+	})
+}
+
+// QueryxContext runs query like sqlx.DB.QueryxContext within a span.
+func (db *DB) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	return run(ctx, db.config, query, func(ctx context.Context) (*sqlx.Rows, error) {
+		return db.DB.QueryxContext(ctx, query, args...)
+	})
+}
+
+// QueryRowxContext runs query like sqlx.DB.QueryRowxContext within a span.
+func (db *DB) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	return queryRow(ctx, db.config, query, func(ctx context.Context) *sqlx.Row {
+		return db.DB.QueryRowxContext(ctx, query, args...)
+	})
+}
+
+// BeginTxx starts a transaction like sqlx.DB.BeginTxx, whose queries are traced as well.
+func (db *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, config: db.config}, nil
+}
+
+// startSpan starts a client span named after the operation of query and the database.
+func startSpan(ctx context.Context, config Config, query string) (context.Context, oteltrace.Span) {
+	operation := operationName(query)
+	ctx, span := trace.Span(ctx, spanName(operation, config.Name), oteltrace.WithSpanKind(oteltrace.SpanKindClient))
+	trace.SetDBAttributes(span, trace.DBInfo{
+		System:    config.System,
+		Name:      config.Name,
+		Operation: operation,
+		Statement: query,
+	})
+	return ctx, span
+}
+
+func run[T any](ctx context.Context, config Config, query string, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, span := startSpan(ctx, config, query)
+	defer span.End()
+
+	result, err := fn(ctx)
+	trace.RecordError(ctx, err)
+	return result, err
+}
+
+func get(ctx context.Context, config Config, query string, fn func(ctx context.Context) error) error {
+	ctx, span := startSpan(ctx, config, query)
+	defer span.End()
+
+	err := fn(ctx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		span.SetAttributes(semconv.DBResponseReturnedRows(0))
+	case err != nil:
+		trace.RecordError(ctx, err)
+	default:
+		span.SetAttributes(semconv.DBResponseReturnedRows(1))
+	}
+	return err
+}
+
+func selectRows(ctx context.Context, config Config, dest any, query string, fn func(ctx context.Context) error) error {
+	ctx, span := startSpan(ctx, config, query)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		trace.RecordError(ctx, err)
+		return err
+	}
+	if v := reflect.Indirect(reflect.ValueOf(dest)); v.Kind() == reflect.Slice {
+		span.SetAttributes(semconv.DBResponseReturnedRows(v.Len()))
+	}
+	return nil
+}
+
+func queryRow(ctx context.Context, config Config, query string, fn func(ctx context.Context) *sqlx.Row) *sqlx.Row {
+	ctx, span := startSpan(ctx, config, query)
+	defer span.End()
+
+	row := fn(ctx)
+	if err := row.Err(); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		trace.RecordError(ctx, err)
+	}
+	return row
+}
+
+// operationName extracts the SQL command (SELECT, INSERT, ...) from a query.
+func operationName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// spanName builds a low-cardinality span name such as "SELECT orders".
+func spanName(operation, name string) string {
+	spanName := strings.TrimSpace(operation + " " + name)
+	if spanName == "" {
+		return defaultSpanName
+	}
+	return spanName
+}
+
+func driverSystem(driverName string) string {
+	if system, ok := driverSystems[driverName]; ok {
+		return system
+	}
+	return driverName
+}
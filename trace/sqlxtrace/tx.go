@@ -0,0 +1,88 @@
+package sqlxtrace
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/jmoiron/sqlx"
+)
+
+// Tx wraps a *sqlx.Tx started with DB.BeginTxx. Like DB, only the context methods below,
+// CommitContext and RollbackContext are traced.
+type Tx struct {
+	*sqlx.Tx
+	config Config
+}
+
+// Unwrap returns the underlying transaction.
+func (tx *Tx) Unwrap() *sqlx.Tx {
+	return tx.Tx
+}
+
+// GetContext runs query like sqlx.Tx.GetContext within a span. sql.ErrNoRows is not
+// recorded as an error.
+func (tx *Tx) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return get(ctx, tx.config, query, func(ctx context.Context) error {
+		return tx.Tx.GetContext(ctx, dest, query, args...)
+	})
+}
+
+// SelectContext runs query like sqlx.Tx.SelectContext within a span recording the number
+// of returned rows.
+func (tx *Tx) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return selectRows(ctx, tx.config, dest, query, func(ctx context.Context) error {
+		return tx.Tx.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+// ExecContext runs query like sql.Tx.ExecContext within a span.
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return run(ctx, tx.config, query, func(ctx context.Context) (sql.Result, error) {
+		return tx.Tx.ExecContext(ctx, query, args...)
+	})
+}
+
+// NamedExecContext runs the named query like sqlx.Tx.NamedExecContext within a span.
+func (tx *Tx) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
+	return run(ctx, tx.config, query, func(ctx context.Context) (sql.Result, error) {
+		return tx.Tx.NamedExecContext(ctx, query, arg)
+	})
+}
+
+// QueryxContext runs query like sqlx.Tx.QueryxContext within a span.
+func (tx *Tx) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	return run(ctx, tx.config, query, func(ctx context.Context) (*sqlx.Rows, error) {
+		return tx.Tx.QueryxContext(ctx, query, args...)
+	})
+}
+
+// QueryRowxContext runs query like sqlx.Tx.QueryRowxContext within a span.
+func (tx *Tx) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	return queryRow(ctx, tx.config, query, func(ctx context.Context) *sqlx.Row {
+		return tx.Tx.QueryRowxContext(ctx, query, args...)
+	})
+}
+
+// CommitContext commits the transaction within a span. database/sql does not take a
+// context for commits, ctx only parents the span.
+func (tx *Tx) CommitContext(ctx context.Context) error {
+	_, err := run(ctx, tx.config, operationCommit, func(context.Context) (struct{}, error) {
+		return struct{}{}, tx.Tx.Commit()
+	})
+	return err
+}
+
+// RollbackContext rolls the transaction back within a span. sql.ErrTxDone, returned when
+// the transaction was already committed, e.g. by a deferred rollback, is not recorded.
+func (tx *Tx) RollbackContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, tx.config, operationRollback)
+	defer span.End()
+
+	err := tx.Tx.Rollback()
+	if !errors.Is(err, sql.ErrTxDone) {
+		trace.RecordError(ctx, err)
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package trace
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultStageBatchSize = 1000
+	stageBatchEvent       = "stage.batch"
+
+	stageItemsKey        = attribute.Key("stage.items")
+	stageErrorsKey       = attribute.Key("stage.errors")
+	stageBatchItemsKey   = attribute.Key("stage.batch.items")
+	stageBatchErrorsKey  = attribute.Key("stage.batch.errors")
+	stageDurationP50Key  = attribute.Key("stage.duration.p50_ms")
+	stageDurationP90Key  = attribute.Key("stage.duration.p90_ms")
+	stageDurationP99Key  = attribute.Key("stage.duration.p99_ms")
+	stageDurationMaxKey  = attribute.Key("stage.duration.max_ms")
+	stageDurationMeanKey = attribute.Key("stage.duration.mean_ms")
+)
+
+// StageSpan aggregates the item-level operations of a pipeline stage into one span
+// instead of one span per item. Every batchSize items, a stage.batch event reports the
+// item and error counts and duration percentiles of the batch. It is safe for concurrent
+// use by the workers of the stage.
+type StageSpan struct {
+	span      oteltrace.Span
+	batchSize int
+
+	mu        sync.Mutex
+	durations []time.Duration
+	errors    int
+	items     int64
+	errTotal  int64
+	ended     bool
+}
+
+// StartStage starts a span for a pipeline stage. batchSize is the number of items per
+// stage.batch event, 1000 when not positive. End the stage with End.
+func StartStage(ctx context.Context, name string, batchSize int) (context.Context, *StageSpan) {
+	if batchSize <= 0 {
+		batchSize = defaultStageBatchSize
+	}
+	ctx, span := Span(ctx, name)
+	return ctx, &StageSpan{
+		span:      span,
+		batchSize: batchSize,
+		durations: make([]time.Duration, 0, batchSize),
+	}
+}
+
+// Record adds an item that took d and failed with err, nil on success.
+func (s *StageSpan) Record(d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+	s.durations = append(s.durations, d)
+	s.items++
+	if err != nil {
+		s.errors++
+		s.errTotal++
+	}
+	if len(s.durations) >= s.batchSize {
+		s.flush()
+	}
+}
+
+// Time runs fn, records it as an item and returns its error.
+func (s *StageSpan) Time(fn func() error) error {
+	start := time.Now()
+	err := fn()
+	s.Record(time.Since(start), err)
+	return err
+}
+
+// End reports the pending items, sets stage.items and stage.errors and ends the span.
+func (s *StageSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+	s.ended = true
+	s.flush()
+	s.span.SetAttributes(stageItemsKey.Int64(s.items), stageErrorsKey.Int64(s.errTotal))
+	s.span.End()
+}
+
+// flush adds the stage.batch event for the pending items. The caller holds s.mu.
+func (s *StageSpan) flush() {
+	if len(s.durations) == 0 {
+		return
+	}
+
+	slices.Sort(s.durations)
+	var total time.Duration
+	for _, d := range s.durations {
+		total += d
+	}
+
+	s.span.AddEvent(stageBatchEvent, oteltrace.WithAttributes(
+		stageBatchItemsKey.Int(len(s.durations)),
+		stageBatchErrorsKey.Int(s.errors),
+		stageDurationP50Key.Float64(milliseconds(percentile(s.durations, 0.50))),
+		stageDurationP90Key.Float64(milliseconds(percentile(s.durations, 0.90))),
+		stageDurationP99Key.Float64(milliseconds(percentile(s.durations, 0.99))),
+		stageDurationMaxKey.Float64(milliseconds(s.durations[len(s.durations)-1])),
+		stageDurationMeanKey.Float64(milliseconds(total/time.Duration(len(s.durations)))),
+	))
+
+	s.durations = s.durations[:0]
+	s.errors = 0
+}
+
+// percentile returns the nearest-rank percentile p of sorted, which must not be empty.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
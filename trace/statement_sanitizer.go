@@ -0,0 +1,244 @@
+package trace
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	StatementObfuscationNone     = "none"
+	StatementObfuscationLiterals = "literals"
+	StatementObfuscationFull     = "full"
+
+	defaultStatementMaxLength = 2048
+	statementPlaceholder      = '?'
+)
+
+// StatementObfuscation selects how database statements are sanitized before they are
+// recorded as db.query.text: kept as is, with literals replaced by placeholders, or
+// reduced to the operation keyword.
+type StatementObfuscation struct {
+	value string
+}
+
+func NewStatementObfuscation(value string) (StatementObfuscation, error) {
+	switch value {
+	case StatementObfuscationNone, StatementObfuscationLiterals, StatementObfuscationFull:
+		return StatementObfuscation{value: value}, nil
+	default:
+		return StatementObfuscation{}, fmt.Errorf("%w: %s", ErrInvalidStatementObfuscation, value)
+	}
+}
+
+func (o StatementObfuscation) String() string {
+	return o.value
+}
+
+func (o StatementObfuscation) IsZero() bool {
+	return o.value == ""
+}
+
+// statementSanitizer holds the sanitization settings selected at Initialize.
+type statementSanitizer struct {
+	obfuscation StatementObfuscation
+	maxLength   int
+}
+
+var defaultStatementSanitizer = statementSanitizer{
+	obfuscation: StatementObfuscation{value: StatementObfuscationLiterals},
+	maxLength:   defaultStatementMaxLength,
+}
+
+// SanitizeStatement applies the configured StatementObfuscation and StatementMaxLength to a
+// SQL or CQL statement. The database integrations call it for db.query.text; custom
+// instrumentation should too. Before Initialize, literals are replaced and the length is capped.
+func SanitizeStatement(statement string) string {
+	globalMutex.RLock()
+	sanitizer := globalStatementSanitizer
+	globalMutex.RUnlock()
+
+	if sanitizer.obfuscation.IsZero() {
+		sanitizer = defaultStatementSanitizer
+	}
+	return sanitizer.sanitize(statement)
+}
+
+func (s statementSanitizer) sanitize(statement string) string {
+	switch s.obfuscation.value {
+	case StatementObfuscationNone:
+	case StatementObfuscationFull:
+		statement = statementOperation(statement)
+	default:
+		statement = replaceLiterals(statement)
+	}
+	return truncateUTF8(statement, s.maxLength)
+}
+
+// statementOperation returns the leading keyword of the statement, e.g. SELECT.
+func statementOperation(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// truncateUTF8 cuts s to at most maxLength bytes without splitting a rune. A maxLength of
+// 0 or less means no limit.
+func truncateUTF8(s string, maxLength int) string {
+	if maxLength <= 0 || len(s) <= maxLength {
+		return s
+	}
+	cut := maxLength
+	for cut > 0 && !utf8RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+func utf8RuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// replaceLiterals replaces string, numeric, and blob literals with placeholders and drops
+// comments, so bound data never ends up in span attributes. String literals include
+// PostgreSQL dollar-quoted strings ($$text$$, $tag$text$tag$) and MySQL backslash escapes
+// ('it\'s'). Double-quoted and backquoted identifiers and bind parameters ($1, :name,
+// @p1) are kept.
+func replaceLiterals(statement string) string {
+	var b strings.Builder
+	b.Grow(len(statement))
+
+	runes := []rune(statement)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'':
+			i = skipQuoted(runes, i, '\'', true)
+			b.WriteRune(statementPlaceholder)
+		case r == '$' && dollarQuoteTag(runes, i) != "":
+			i = skipDollarQuoted(runes, i)
+			b.WriteRune(statementPlaceholder)
+		case r == '"' || r == '`':
+			end := skipQuoted(runes, i, r, false)
+			b.WriteString(string(runes[i : end+1]))
+			i = end
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			i = skipLineComment(runes, i)
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i = skipBlockComment(runes, i)
+		case isLiteralStart(runes, i):
+			i = skipLiteral(runes, i)
+			b.WriteRune(statementPlaceholder)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// skipQuoted returns the index of the closing quote of the quoted text starting at i.
+// A doubled quote is an escaped quote and is treated as part of the text, as is any rune
+// after a backslash with backslashEscapes. Backslash escaping a standard SQL string that
+// ends in a backslash replaces more than the literal, never less.
+func skipQuoted(runes []rune, i int, quote rune, backslashEscapes bool) int {
+	for j := i + 1; j < len(runes); j++ {
+		if backslashEscapes && runes[j] == '\\' {
+			j++
+			continue
+		}
+		if runes[j] != quote {
+			continue
+		}
+		if j+1 < len(runes) && runes[j+1] == quote {
+			j++
+			continue
+		}
+		return j
+	}
+	return len(runes) - 1
+}
+
+// dollarQuoteTag returns the opening delimiter of the dollar-quoted string starting at i,
+// e.g. "$$" or "$tag$", or "" when i does not start one, as for $1 or a $ in an identifier.
+func dollarQuoteTag(runes []rune, i int) string {
+	if i > 0 && isIdentifierRune(runes[i-1]) {
+		return ""
+	}
+	for j := i + 1; j < len(runes); j++ {
+		switch {
+		case runes[j] == '$':
+			return string(runes[i : j+1])
+		case unicode.IsDigit(runes[j]) && j == i+1, !isIdentifierRune(runes[j]):
+			return ""
+		}
+	}
+	return ""
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$'
+}
+
+// skipDollarQuoted returns the index of the last rune of the closing delimiter of the
+// dollar-quoted string starting at i.
+func skipDollarQuoted(runes []rune, i int) int {
+	tag := []rune(dollarQuoteTag(runes, i))
+	for j := i + len(tag); j+len(tag) <= len(runes); j++ {
+		if slices.Equal(runes[j:j+len(tag)], tag) {
+			return j + len(tag) - 1
+		}
+	}
+	return len(runes) - 1
+}
+
+// skipLineComment returns the index of the last rune of the -- comment starting at i,
+// keeping the line break.
+func skipLineComment(runes []rune, i int) int {
+	for j := i; j < len(runes); j++ {
+		if runes[j] == '\n' {
+			return j - 1
+		}
+	}
+	return len(runes) - 1
+}
+
+// skipBlockComment returns the index of the closing slash of the comment starting at i.
+func skipBlockComment(runes []rune, i int) int {
+	for j := i + 2; j+1 < len(runes); j++ {
+		if runes[j] == '*' && runes[j+1] == '/' {
+			return j + 1
+		}
+	}
+	return len(runes) - 1
+}
+
+// isLiteralStart reports whether a numeric or blob literal starts at i.
+// Digits that are part of an identifier (e.g. table_v2) or a bind parameter are not literals.
+func isLiteralStart(runes []rune, i int) bool {
+	r := runes[i]
+	if !unicode.IsDigit(r) && (r != '-' || i+1 >= len(runes) || !unicode.IsDigit(runes[i+1])) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev := runes[i-1]
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev) && !strings.ContainsRune("_.$:@", prev)
+}
+
+// skipLiteral returns the index of the last rune of the literal starting at i.
+func skipLiteral(runes []rune, i int) int {
+	j := i + 1
+	for j < len(runes) && isLiteralRune(runes[j]) {
+		j++
+	}
+	return j - 1
+}
+
+func isLiteralRune(r rune) bool {
+	return unicode.IsDigit(r) || unicode.IsLetter(r) || r == '.' || r == '-'
+}
@@ -0,0 +1,129 @@
+package trace
+
+import "testing"
+
+func TestReplaceLiterals(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      string
+	}{
+		{
+			name:      "string and numeric literals",
+			statement: "SELECT * FROM users WHERE name = 'bob' AND age > 42",
+			want:      "SELECT * FROM users WHERE name = ? AND age > ?",
+		},
+		{
+			name:      "doubled quote",
+			statement: "SELECT 'it''s'",
+			want:      "SELECT ?",
+		},
+		{
+			name:      "backslash escaped quote",
+			statement: `SELECT * FROM t WHERE a = 'it\'s' AND b = 'x'`,
+			want:      "SELECT * FROM t WHERE a = ? AND b = ?",
+		},
+		{
+			name:      "escaped backslash before closing quote",
+			statement: `SELECT 'C:\\' , 1`,
+			want:      "SELECT ? , ?",
+		},
+		{
+			name:      "empty dollar quote",
+			statement: "SELECT $$it's secret$$, 1",
+			want:      "SELECT ?, ?",
+		},
+		{
+			name:      "tagged dollar quote",
+			statement: "DO $fn$ BEGIN PERFORM 'x'; $$inner$$ END $fn$",
+			want:      "DO ?",
+		},
+		{
+			name:      "unterminated dollar quote",
+			statement: "SELECT $tag$secret",
+			want:      "SELECT ?",
+		},
+		{
+			name:      "bind parameters",
+			statement: "SELECT * FROM t WHERE a = $1 AND b = :name AND c = @p1",
+			want:      "SELECT * FROM t WHERE a = $1 AND b = :name AND c = @p1",
+		},
+		{
+			name:      "dollar in identifier",
+			statement: "SELECT a$b$ FROM t",
+			want:      "SELECT a$b$ FROM t",
+		},
+		{
+			name:      "identifiers kept",
+			statement: `SELECT "col1", ` + "`col2`" + ` FROM table_v2`,
+			want:      `SELECT "col1", ` + "`col2`" + ` FROM table_v2`,
+		},
+		{
+			name:      "comments dropped",
+			statement: "SELECT 1 -- 'secret'\n/* token */FROM t",
+			want:      "SELECT ? \nFROM t",
+		},
+		{
+			name:      "negative and hex literals",
+			statement: "UPDATE t SET a = -1.5, b = 0xFF",
+			want:      "UPDATE t SET a = ?, b = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceLiterals(tt.statement); got != tt.want {
+				t.Errorf("replaceLiterals(%q) = %q, want %q", tt.statement, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatementSanitizer(t *testing.T) {
+	tests := []struct {
+		name        string
+		obfuscation string
+		maxLength   int
+		statement   string
+		want        string
+	}{
+		{
+			name:        "none",
+			obfuscation: StatementObfuscationNone,
+			statement:   "SELECT 'x'",
+			want:        "SELECT 'x'",
+		},
+		{
+			name:        "literals",
+			obfuscation: StatementObfuscationLiterals,
+			statement:   "SELECT 'x'",
+			want:        "SELECT ?",
+		},
+		{
+			name:        "full",
+			obfuscation: StatementObfuscationFull,
+			statement:   "  select * from t where a = 'x'",
+			want:        "SELECT",
+		},
+		{
+			name:        "truncated without splitting a rune",
+			obfuscation: StatementObfuscationNone,
+			maxLength:   8,
+			statement:   "SELECT é",
+			want:        "SELECT ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obfuscation, err := NewStatementObfuscation(tt.obfuscation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sanitizer := statementSanitizer{obfuscation: obfuscation, maxLength: tt.maxLength}
+			if got := sanitizer.sanitize(tt.statement); got != tt.want {
+				t.Errorf("sanitize(%q) = %q, want %q", tt.statement, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package trace
+
+import "fmt"
+
+// SetStrict enables or disables strict mode. In strict mode Span panics when it is
+// called before Initialize instead of silently returning a no-op span, so services
+// that forgot to initialize tracing fail fast instead of running without telemetry.
+// Call it at the very start of main, before any code that may create spans.
+func SetStrict(enabled bool) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+	strictMode = enabled
+}
+
+// IsStrict returns true if strict mode is enabled.
+func IsStrict() bool {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return strictMode
+}
+
+// notInitializedError returns the error Span panics with in strict mode.
+func notInitializedError(name string) error {
+	return fmt.Errorf("%w: span %q started before Initialize (strict mode)", ErrNotInitialized, name)
+}
@@ -0,0 +1,131 @@
+package trace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	structAttrTag    = "otel"
+	structAttrSkip   = "-"
+	structAttrRedact = "redact"
+)
+
+// structAttrField is a tagged field of a struct type.
+type structAttrField struct {
+	index  []int
+	key    attribute.Key
+	redact bool
+}
+
+// structAttrFields caches the tagged fields per struct type.
+var structAttrFields sync.Map // reflect.Type -> []structAttrField
+
+// AttrsFromStruct returns an attribute per field of the struct v, or pointer to struct,
+// tagged `otel:"<key>"`, converted with Attr. Fields tagged `otel:"<key>,redact"` are
+// recorded as the SHA-256 hash of their value, so they can be correlated but not read, and
+// fields tagged `otel:"-"` or untagged are skipped. Fields of embedded structs are
+// included. It returns nil when v is not a struct or is a nil pointer.
+//
+//	type Order struct {
+//		ID    uuid.UUID `otel:"order.id"`
+//		Email string    `otel:"customer.email,redact"`
+//		Notes string    `otel:"-"`
+//	}
+func AttrsFromStruct(v any) []attribute.KeyValue {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := structFields(rv.Type())
+	attrs := make([]attribute.KeyValue, 0, len(fields))
+	for _, field := range fields {
+		fv, ok := fieldByIndex(rv, field.index)
+		if !ok || !fv.CanInterface() {
+			continue
+		}
+		value := attrValue(fv.Interface())
+		if field.redact {
+			sum := sha256.Sum256([]byte(value.Emit()))
+			value = attribute.StringValue(hex.EncodeToString(sum[:]))
+		}
+		attrs = append(attrs, attribute.KeyValue{Key: field.key, Value: value})
+	}
+	return attrs
+}
+
+func structFields(t reflect.Type) []structAttrField {
+	if cached, ok := structAttrFields.Load(t); ok {
+		return cached.([]structAttrField)
+	}
+	fields := appendStructFields(nil, t, nil, map[reflect.Type]bool{t: true})
+	structAttrFields.Store(t, fields)
+	return fields
+}
+
+// appendStructFields appends the tagged fields of t, descending into embedded structs once
+// per type so recursive embedding such as type Node struct{ *Node } terminates.
+func appendStructFields(
+	fields []structAttrField,
+	t reflect.Type,
+	index []int,
+	visited map[reflect.Type]bool,
+) []structAttrField {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup(structAttrTag)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if !tagged {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if f.Anonymous && embedded.Kind() == reflect.Struct && !visited[embedded] {
+				visited[embedded] = true
+				fields = appendStructFields(fields, embedded, fieldIndex, visited)
+			}
+			continue
+		}
+		if !f.IsExported() || tag == structAttrSkip {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			continue
+		}
+		fields = append(fields, structAttrField{
+			index:  fieldIndex,
+			key:    attribute.Key(name),
+			redact: options == structAttrRedact,
+		})
+	}
+	return fields
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex reporting false on nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,96 @@
+package trace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type testAudit struct {
+	CreatedBy string `otel:"audit.created_by"`
+}
+
+type testOrder struct {
+	testAudit
+	*testMeta
+
+	ID       string `otel:"order.id"`
+	Total    int    `otel:"order.total"`
+	Email    string `otel:"customer.email,redact"`
+	Notes    string `otel:"-"`
+	Internal string
+	secret   string `otel:"order.secret"`
+}
+
+type testMeta struct {
+	Region string `otel:"order.region"`
+}
+
+type testNode struct {
+	*testNode
+	ID string `otel:"node.id"`
+}
+
+func TestAttrsFromStruct(t *testing.T) {
+	emailHash := sha256.Sum256([]byte("bob@example.com"))
+
+	tests := []struct {
+		name  string
+		value any
+		want  []attribute.KeyValue
+	}{
+		{
+			name: "tagged fields",
+			value: testOrder{
+				testAudit: testAudit{CreatedBy: "alice"},
+				ID:        "o-1",
+				Total:     42,
+				Email:     "bob@example.com",
+				Notes:     "leave at door",
+				Internal:  "x",
+				secret:    "y",
+			},
+			want: []attribute.KeyValue{
+				attribute.String("audit.created_by", "alice"),
+				attribute.String("order.id", "o-1"),
+				attribute.Int("order.total", 42),
+				attribute.String("customer.email", hex.EncodeToString(emailHash[:])),
+			},
+		},
+		{
+			name:  "pointer with embedded pointer",
+			value: &testOrder{testMeta: &testMeta{Region: "eu"}, ID: "o-2"},
+			want: []attribute.KeyValue{
+				attribute.String("audit.created_by", ""),
+				attribute.String("order.region", "eu"),
+				attribute.String("order.id", "o-2"),
+				attribute.Int("order.total", 0),
+				attribute.String("customer.email", hex.EncodeToString(sha256.New().Sum(nil))),
+			},
+		},
+		{
+			name:  "recursive embedding",
+			value: testNode{testNode: &testNode{ID: "inner"}, ID: "outer"},
+			want:  []attribute.KeyValue{attribute.String("node.id", "outer")},
+		},
+		{name: "nil pointer", value: (*testOrder)(nil)},
+		{name: "not a struct", value: "order"},
+		{name: "nil", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AttrsFromStruct(tt.value)
+			if len(got) != len(tt.want) {
+				t.Fatalf("AttrsFromStruct = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("attribute %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
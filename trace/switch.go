@@ -0,0 +1,30 @@
+package trace
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	tracingDisabled atomic.Bool
+	noopTracer      = noop.NewTracerProvider().Tracer("")
+)
+
+// Disable makes Span return no-op spans and drops spans started through the OpenTracing
+// bridge or Provider without shutting down the provider, e.g. during an incident where the
+// telemetry pipeline itself is the problem. Incoming trace context is still propagated.
+// Spans already started are exported normally. Shutdown enables tracing again.
+func Disable() {
+	tracingDisabled.Store(true)
+}
+
+// Enable undoes Disable.
+func Enable() {
+	tracingDisabled.Store(false)
+}
+
+// IsEnabled reports whether Span creates real spans, i.e. Disable is not in effect.
+func IsEnabled() bool {
+	return !tracingDisabled.Load()
+}
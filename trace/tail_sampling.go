@@ -0,0 +1,206 @@
+package trace
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultMaxBufferedTraces = 10000
+	defaultMaxDecidedTraces  = 10000
+	defaultMaxSpansPerTrace  = 1000
+)
+
+// recordOnlySampler turns Drop decisions of the wrapped sampler into RecordOnly, so
+// spans the head sampler would discard are still recorded and can be kept at end time.
+type recordOnlySampler struct {
+	sampler sdktrace.Sampler
+}
+
+func newRecordOnlySampler(sampler sdktrace.Sampler) sdktrace.Sampler {
+	return &recordOnlySampler{sampler: sampler}
+}
+
+func (s *recordOnlySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.sampler.ShouldSample(p)
+	if result.Decision == sdktrace.Drop {
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
+}
+
+func (s *recordOnlySampler) Description() string {
+	return "RecordOnly{" + s.sampler.Description() + "}"
+}
+
+// tailSamplingPolicy decides at the end of a local root span whether its trace is kept.
+type tailSamplingPolicy struct {
+	keepErrors       bool
+	latencyThreshold time.Duration
+}
+
+func (p tailSamplingPolicy) keep(root sdktrace.ReadOnlySpan) bool {
+	if p.keepErrors && root.Status().Code == codes.Error {
+		return true
+	}
+	return p.latencyThreshold > 0 && root.EndTime().Sub(root.StartTime()) > p.latencyThreshold
+}
+
+// tailSamplingProcessor buffers recorded but unsampled spans per trace until the local
+// root span ends, then forwards the whole trace to the next processor if the policy
+// keeps it. Spans sampled by the head sampler are forwarded immediately. A trace buffering
+// defaultMaxSpansPerTrace spans before its root ends is dropped, so that a runaway trace
+// cannot hold unbounded memory.
+type tailSamplingProcessor struct {
+	next   sdktrace.SpanProcessor
+	policy tailSamplingPolicy
+
+	mu      sync.Mutex
+	pending map[oteltrace.TraceID]*list.Element // buffered traces, oldest first in order
+	order   *list.List
+	decided map[oteltrace.TraceID]*list.Element // traces whose root already ended
+	history *list.List
+}
+
+type pendingTrace struct {
+	traceID oteltrace.TraceID
+	spans   []sdktrace.ReadOnlySpan
+}
+
+type decidedTrace struct {
+	traceID oteltrace.TraceID
+	keep    bool
+}
+
+var _ sdktrace.SpanProcessor = (*tailSamplingProcessor)(nil)
+
+func newTailSamplingProcessor(next sdktrace.SpanProcessor, policy tailSamplingPolicy) *tailSamplingProcessor {
+	return &tailSamplingProcessor{
+		next:    next,
+		policy:  policy,
+		pending: make(map[oteltrace.TraceID]*list.Element),
+		order:   list.New(),
+		decided: make(map[oteltrace.TraceID]*list.Element),
+		history: list.New(),
+	}
+}
+
+func (p *tailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+
+	traceID := s.SpanContext().TraceID()
+	isLocalRoot := !s.Parent().IsValid() || s.Parent().IsRemote()
+
+	p.mu.Lock()
+	if elem, ok := p.decided[traceID]; ok {
+		keep := elem.Value.(*decidedTrace).keep
+		p.mu.Unlock()
+		if keep {
+			p.next.OnEnd(sampledSpan{ReadOnlySpan: s})
+		}
+		return
+	}
+
+	spans := p.buffer(traceID, s)
+	if !isLocalRoot {
+		p.mu.Unlock()
+		return
+	}
+
+	keep := p.policy.keep(s)
+	p.remember(traceID, keep)
+	p.mu.Unlock()
+
+	if keep {
+		for _, span := range spans {
+			p.next.OnEnd(sampledSpan{ReadOnlySpan: span})
+		}
+	}
+}
+
+// buffer adds the span to its pending trace. When the local root span is passed the
+// pending trace is removed and all its spans are returned. A trace reaching
+// defaultMaxSpansPerTrace is removed and decided as dropped. Must be called with mu held.
+func (p *tailSamplingProcessor) buffer(traceID oteltrace.TraceID, s sdktrace.ReadOnlySpan) []sdktrace.ReadOnlySpan {
+	elem, ok := p.pending[traceID]
+	if !ok {
+		if p.order.Len() >= defaultMaxBufferedTraces {
+			// drop the oldest trace, its root span never ended in time
+			oldest := p.order.Front()
+			p.order.Remove(oldest)
+			delete(p.pending, oldest.Value.(*pendingTrace).traceID)
+		}
+		elem = p.order.PushBack(&pendingTrace{traceID: traceID})
+		p.pending[traceID] = elem
+	}
+
+	buffered := elem.Value.(*pendingTrace)
+	buffered.spans = append(buffered.spans, s)
+
+	switch {
+	case !s.Parent().IsValid() || s.Parent().IsRemote():
+		p.order.Remove(elem)
+		delete(p.pending, traceID)
+	case len(buffered.spans) >= defaultMaxSpansPerTrace:
+		p.order.Remove(elem)
+		delete(p.pending, traceID)
+		p.remember(traceID, false)
+		return nil
+	}
+	return buffered.spans
+}
+
+// remember records the decision so spans ending after the root follow it.
+// Must be called with mu held.
+func (p *tailSamplingProcessor) remember(traceID oteltrace.TraceID, keep bool) {
+	if p.history.Len() >= defaultMaxDecidedTraces {
+		oldest := p.history.Front()
+		p.history.Remove(oldest)
+		delete(p.decided, oldest.Value.(*decidedTrace).traceID)
+	}
+	p.decided[traceID] = p.history.PushBack(&decidedTrace{traceID: traceID, keep: keep})
+}
+
+func (p *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// sampledSpan reports the span as sampled so that the batch processor exports it.
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() oteltrace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
+
+// tailSamplingEnabled reports whether any end-time sampling policy is configured.
+func tailSamplingEnabled(config TracerConfig) bool {
+	return config.ErrorBiasedSampling || config.LatencyThreshold > 0
+}
+
+// newTailSamplingPolicy builds the policy from the configuration.
+func newTailSamplingPolicy(config TracerConfig) tailSamplingPolicy {
+	return tailSamplingPolicy{
+		keepErrors:       config.ErrorBiasedSampling,
+		latencyThreshold: config.LatencyThreshold,
+	}
+}
@@ -0,0 +1,48 @@
+package trace
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const defaultTenantBaggageKey = "tenant.id"
+
+// tenantSampler applies per-tenant sample rates, reading the tenant from a baggage
+// member of the parent context. Spans without a known tenant use the fallback sampler.
+type tenantSampler struct {
+	baggageKey string
+	samplers   map[string]sdktrace.Sampler
+	fallback   sdktrace.Sampler
+}
+
+func newTenantSampler(
+	baggageKey string,
+	rates map[string]float64,
+	ratio func(rate float64) sdktrace.Sampler,
+	fallback sdktrace.Sampler,
+) sdktrace.Sampler {
+	samplers := make(map[string]sdktrace.Sampler, len(rates))
+	for tenant, rate := range rates {
+		samplers[tenant] = ratio(rate)
+	}
+	return &tenantSampler{
+		baggageKey: baggageKey,
+		samplers:   samplers,
+		fallback:   fallback,
+	}
+}
+
+func (s *tenantSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	tenant := baggage.FromContext(p.ParentContext).Member(s.baggageKey).Value()
+	if sampler, ok := s.samplers[tenant]; ok && tenant != "" {
+		return sampler.ShouldSample(p)
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *tenantSampler) Description() string {
+	return fmt.Sprintf("TenantSampler{key=%s,tenants=%d,fallback=%s}",
+		s.baggageKey, len(s.samplers), s.fallback.Description())
+}
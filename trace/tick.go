@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tickOverrunEvent = "tick.overrun"
+	tickSkippedEvent = "tick.skipped"
+
+	tickIterationKey = attribute.Key("tick.iteration")
+	tickIntervalKey  = attribute.Key("tick.interval_ms")
+	tickDurationKey  = attribute.Key("tick.duration_ms")
+	tickSkippedKey   = attribute.Key("tick.skipped")
+	tickLateKey      = attribute.Key("tick.late_ms")
+)
+
+// Tick runs fn every interval until ctx is done, each run under a new root span named
+// name, for maintenance loops in background goroutines. A run lasting longer than the
+// interval gets a tick.overrun event, and the run after ticks dropped because of it gets a
+// tick.skipped event with their count. The error of fn is recorded on its span.
+//
+// Tick blocks until ctx is done. A run in progress is not cancelled with ctx but completes
+// before Tick returns. The interval must be positive.
+func Tick(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	runCtx := context.WithoutCancel(ctx)
+	last := time.Now()
+	for iteration := int64(0); ; iteration++ {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			late := now.Sub(last) - interval
+			last = now
+			runTick(runCtx, name, interval, iteration, late, fn)
+		}
+	}
+}
+
+// runTick runs one iteration of Tick. late is how much later than one interval after the
+// previous tick this tick came.
+func runTick(
+	ctx context.Context,
+	name string,
+	interval time.Duration,
+	iteration int64,
+	late time.Duration,
+	fn func(ctx context.Context) error,
+) {
+	ctx, span := Span(ctx, name,
+		oteltrace.WithNewRoot(),
+		oteltrace.WithAttributes(
+			tickIterationKey.Int64(iteration),
+			tickIntervalKey.Int64(interval.Milliseconds()),
+		),
+	)
+	defer span.End()
+
+	if skipped := int64(late / interval); skipped > 0 {
+		span.AddEvent(tickSkippedEvent, oteltrace.WithAttributes(
+			tickSkippedKey.Int64(skipped),
+			tickLateKey.Int64(late.Milliseconds()),
+		))
+	}
+
+	start := time.Now()
+	err := fn(ctx)
+	duration := time.Since(start)
+
+	span.SetAttributes(tickDurationKey.Int64(duration.Milliseconds()))
+	if duration > interval {
+		span.AddEvent(tickOverrunEvent, oteltrace.WithAttributes(tickDurationKey.Int64(duration.Milliseconds())))
+	}
+	if err != nil {
+		RecordError(ctx, err)
+	}
+}
@@ -0,0 +1,60 @@
+package trace
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SpanAt is like Span but starts the span at the given time, for operations that began
+// before they could be instrumented.
+func SpanAt(
+	ctx context.Context,
+	name string,
+	start time.Time,
+	opts ...oteltrace.SpanStartOption,
+) (context.Context, oteltrace.Span) {
+	return Span(ctx, name, append(slices.Clip(opts), oteltrace.WithTimestamp(start))...)
+}
+
+// EndAt ends the span at the given time.
+func EndAt(span oteltrace.Span, end time.Time, opts ...oteltrace.SpanEndOption) {
+	span.End(append(slices.Clip(opts), oteltrace.WithTimestamp(end))...)
+}
+
+// RecordSpan records an operation that already happened, e.g. parsed from upstream logs
+// or batch records, as a span from start to end. The returned context carries the span
+// so child operations can be recorded under it.
+func RecordSpan(
+	ctx context.Context,
+	name string,
+	start, end time.Time,
+	opts ...oteltrace.SpanStartOption,
+) context.Context {
+	ctx, span := SpanAt(ctx, name, start, opts...)
+	EndAt(span, end)
+	return ctx
+}
+
+// Stopwatch measures an operation and records it as a span once it is known to be worth
+// recording, e.g. only when it failed or was slow.
+type Stopwatch struct {
+	start time.Time
+}
+
+// StartStopwatch starts measuring from now.
+func StartStopwatch() Stopwatch {
+	return Stopwatch{start: time.Now()}
+}
+
+// Elapsed returns the time since the stopwatch started.
+func (s Stopwatch) Elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+// Record records a span from the stopwatch start until now.
+func (s Stopwatch) Record(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) context.Context {
+	return RecordSpan(ctx, name, s.start, time.Now(), opts...)
+}
@@ -2,31 +2,64 @@ package trace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"reflect"
+	"slices"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 var (
-	globalTracer         oteltrace.Tracer
-	globalTracerProvider *sdktrace.TracerProvider
-	globalExporter       sdktrace.SpanExporter
-	globalMutex          sync.RWMutex
-	initialized          bool
+	globalTracer               oteltrace.Tracer
+	globalTracerProvider       *sdktrace.TracerProvider
+	globalExporter             sdktrace.SpanExporter
+	globalExternalProvider     oteltrace.TracerProvider
+	globalPropagationOnly      bool
+	globalResource             *resource.Resource
+	globalSpanTracker          *spanTracker
+	spanTrackerUsers           int
+	globalSpanWatchdog         *spanWatchdog
+	globalSamplerStats         *samplerStats
+	globalTraceAttributes      *traceAttributes
+	globalDebugHeader          string
+	globalSemconvStability     SemconvStability
+	globalStatementSanitizer   statementSanitizer
+	globalRecordErrorChain     bool
+	globalCodeAttributes       bool
+	globalConfig               TracerConfig
+	globalPipelineMetrics      *pipelineMetrics
+	globalSamplingControl      *samplingControl
+	globalReload               *reloadState
+	openTracingBridgeInstalled bool
+	globalMutex                sync.RWMutex
+	initialized                bool
+	strictMode                 bool
 )
 
 // Initialize configures the global tracer. Must be called before using StartSpan.
 // Returns an error if initialization fails.
 func Initialize(config TracerConfig) error {
+	return InitializeWithResource(config, nil)
+}
+
+// InitializeWithResource is like Initialize but uses the given resource instead of
+// building one from the configuration, so several signals can share the same resource.
+// A nil resource behaves like Initialize.
+func InitializeWithResource(config TracerConfig, res *resource.Resource) error {
 	globalMutex.Lock()
 	defer globalMutex.Unlock()
 
@@ -40,20 +73,92 @@ func Initialize(config TracerConfig) error {
 
 	config.setDefaults()
 
-	res := createResource(config)
+	return initialize(config, res)
+}
+
+// InitializeOnce is like Initialize but succeeds without doing anything when the tracer
+// is already initialized with an equivalent configuration, so several components or tests
+// can initialize tracing. A different configuration returns ErrInitializedWithOtherConfig.
+func InitializeOnce(config TracerConfig) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config.setDefaults()
+
+	if initialized {
+		if !reflect.DeepEqual(config, globalConfig) {
+			return ErrInitializedWithOtherConfig
+		}
+		return nil
+	}
+
+	return initialize(config, nil)
+}
+
+// initialize sets up the global tracer for a validated configuration with defaults applied.
+// The caller holds globalMutex.
+func initialize(config TracerConfig, res *resource.Resource) error {
+	if res == nil {
+		res = NewResource(config)
+	}
+
+	stats := &samplerStats{}
+	attrs := newTraceAttributes()
+	control := newSamplingControl(config.ConsistentSampling)
+
+	var pipeline *pipelineMetrics
+	if config.PipelineMetrics {
+		pipeline = newPipelineMetrics(config.MaxQueueSize)
+	}
 
-	tp, exp, err := newTracerProvider(config, res)
+	reload := &reloadState{attributes: newGlobalAttributes(config.GlobalAttributes)}
+
+	tp, exp, err := newTracerProvider(config, res, stats, attrs, pipeline, control, reload)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrCreateTracerProvider, err)
 	}
 
 	setupGlobalTracing(tp)
+	if pipeline != nil {
+		pipeline.register()
+	}
 
-	globalTracer = tp.Tracer(config.AppName)
+	var provider oteltrace.TracerProvider = tp
+	if config.OpenTracingBridge {
+		provider = installOpenTracingBridge(tp, config.AppName)
+		otel.SetTracerProvider(provider)
+	}
+
+	globalTracer = provider.Tracer(config.AppName)
 	globalTracerProvider = tp
 	globalExporter = exp
+	globalResource = res
+	globalSamplerStats = stats
+	globalTraceAttributes = attrs
+	globalDebugHeader = config.DebugHeader
+	globalSemconvStability = config.SemconvStability
+	globalStatementSanitizer = statementSanitizer{
+		obfuscation: config.StatementObfuscation,
+		maxLength:   config.StatementMaxLength,
+	}
+	globalRecordErrorChain = config.RecordErrorChain
+	globalCodeAttributes = config.CodeAttributes
+	globalConfig = config
+	globalPipelineMetrics = pipeline
+	globalSamplingControl = control
+	globalReload = reload
+	openTracingBridgeInstalled = config.OpenTracingBridge
 	initialized = true
 
+	if config.SpanWatchdogTimeout > 0 {
+		globalSpanWatchdog = newSpanWatchdog(acquireSpanTracker(), config.SpanWatchdogTimeout)
+		globalSpanWatchdog.start()
+	}
+
 	return nil
 }
 
@@ -64,13 +169,26 @@ func MustInitialize(config TracerConfig) {
 	}
 }
 
-// createResource creates and configures the OpenTelemetry resource
-func createResource(config TracerConfig) *resource.Resource {
-	return resource.NewWithAttributes(
-		semconv.SchemaURL,
+// InitializeNoop marks the tracer initialized with a provider that samples and exports
+// nothing and creates no exporter, for tests and tools that only need the API surface.
+// Shutdown works as after Initialize.
+func InitializeNoop() error {
+	return Initialize(TracerConfig{AppName: noopAppName})
+}
+
+// NewResource creates the OpenTelemetry resource describing the service, with the
+// Kubernetes pod, namespace, node and deployment when running in a cluster
+func NewResource(config TracerConfig) *resource.Resource {
+	attrs := []attribute.KeyValue{
 		semconv.ServiceName(config.AppName),
 		semconv.ServiceVersion(config.AppVersion),
-	)
+		semconv.ServiceInstanceID(serviceInstanceID(config)),
+	}
+	if config.GCPProjectID != "" {
+		attrs = append(attrs, gcpProjectIDKey.String(config.GCPProjectID))
+	}
+	attrs = append(attrs, kubernetesAttributes()...)
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
 }
 
 // setupGlobalTracing configures global OpenTelemetry settings
@@ -91,42 +209,157 @@ func newPropagator() propagation.TextMapPropagator {
 func newTracerProvider(
 	config TracerConfig,
 	res *resource.Resource,
+	stats *samplerStats,
+	attrs *traceAttributes,
+	pipeline *pipelineMetrics,
+	control *samplingControl,
+	reload *reloadState,
 ) (*sdktrace.TracerProvider, sdktrace.SpanExporter, error) {
+	control.sampler.store(newSampler(config, control))
+
 	if !config.TraceEnabled {
 		tp := sdktrace.NewTracerProvider(
 			sdktrace.WithResource(res),
-			sdktrace.WithSampler(sdktrace.NeverSample()),
+			sdktrace.WithSampler(newCountingSampler(sdktrace.NeverSample(), stats)),
 		)
 		return tp, nil, nil
 	}
 
-	exp, err := newExporter(config)
+	var nameProcessor *SpanNameProcessor
+	if len(config.SpanNameRules) > 0 {
+		processor, err := NewSpanNameProcessor(config.SpanNameRules)
+		if err != nil {
+			return nil, nil, err
+		}
+		nameProcessor = processor
+	}
+
+	if config.ValidateEndpoint {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultBatchTimeout)
+		err := probeEndpoint(ctx, config)
+		cancel()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	created, err := createExporter(config)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w: %w", ErrCreateExporter, err)
+		return nil, nil, err
 	}
+	// Reload replaces the exporter when the export headers change
+	reload.exporter = newReloadableExporter(created)
+	var exp sdktrace.SpanExporter = reload.exporter
 
 	// Configure batch span processor options
 	batchOptions := []sdktrace.BatchSpanProcessorOption{
 		sdktrace.WithBatchTimeout(config.BatchTimeout),
 		sdktrace.WithMaxExportBatchSize(config.MaxBatchSize),
+		sdktrace.WithMaxQueueSize(config.MaxQueueSize),
 	}
 
-	// Configure sampling
-	sampler := sdktrace.TraceIDRatioBased(config.SampleRate)
-	if config.SampleRate >= defaultSampleRate {
-		sampler = sdktrace.AlwaysSample()
+	providerOptions := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(newCountingSampler(&control.sampler, stats)),
+		sdktrace.WithSpanProcessor(reload.attributes),
+		sdktrace.WithSpanProcessor(attrs),
+		sdktrace.WithSpanProcessor(globalHooks),
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exp, batchOptions...)),
-		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sampler),
-	)
+	// Span name rules run before the batch processor so exported spans carry the rewritten names
+	if nameProcessor != nil {
+		providerOptions = append(providerOptions, sdktrace.WithSpanProcessor(nameProcessor))
+	}
+
+	if config.SpanMetrics {
+		providerOptions = append(providerOptions, sdktrace.WithSpanProcessor(newSpanMetricsProcessor()))
+	}
+
+	var exportProcessor sdktrace.SpanProcessor
+	if pipeline != nil {
+		providerOptions = append(providerOptions, sdktrace.WithSpanProcessor(&inFlightProcessor{metrics: pipeline}))
+	}
+
+	switch {
+	case config.SyncExport:
+		// nothing is queued, only the in-flight gauge is meaningful
+		exportProcessor = sdktrace.NewSimpleSpanProcessor(exp)
+	case pipeline != nil:
+		batcher := sdktrace.NewBatchSpanProcessor(&queueExporter{SpanExporter: exp, metrics: pipeline}, batchOptions...)
+		exportProcessor = &queueProcessor{next: batcher, metrics: pipeline}
+	default:
+		exportProcessor = sdktrace.NewBatchSpanProcessor(exp, batchOptions...)
+	}
+
+	if config.AttributeBudget.enabled() {
+		exportProcessor = newAttributeBudgetProcessor(exportProcessor, config.AttributeBudget)
+	}
+
+	if len(config.SlowSpanThresholds) > 0 {
+		exportProcessor = newSlowSpanProcessor(exportProcessor, config.SlowSpanThresholds)
+	}
+
+	if tailSamplingEnabled(config) {
+		exportProcessor = newTailSamplingProcessor(exportProcessor, newTailSamplingPolicy(config))
+	}
+
+	providerOptions = append(providerOptions, sdktrace.WithSpanProcessor(exportProcessor))
+
+	tp := sdktrace.NewTracerProvider(providerOptions...)
 
 	return tp, exp, nil
 }
 
-// newExporter creates a new OTLP exporter (gRPC or HTTP based on config)
+// newSampler creates the sampler for the configured sample rates, adjustable at runtime through control
+func newSampler(config TracerConfig, control *samplingControl) sdktrace.Sampler {
+	// SampleRate of defaultSampleRate and above samples every trace without ConsistentSampling
+	base := control.rateSampler(config.SampleRate)
+	if len(config.SampleRateSchedule) > 0 {
+		base = newScheduleSampler(config.SampleRateSchedule, control.ratio, base, control.started)
+	}
+	var sampler sdktrace.Sampler = &controlledSampler{base: base, control: control}
+
+	if len(config.KindSampleRates) > 0 {
+		sampler = newKindSampler(config.KindSampleRates, control.ratio, sampler)
+	}
+
+	if len(config.TenantSampleRates) > 0 {
+		sampler = newTenantSampler(config.TenantBaggageKey, config.TenantSampleRates, control.ratio, sampler)
+	}
+
+	// Contexts marked by ForceSample or the debug header are sampled whatever the rates
+	sampler = newForceSampler(sampler)
+
+	// Spans dropped by the head sampler are still recorded so they can be kept at end time
+	// or counted by the span metrics processor
+	if tailSamplingEnabled(config) || config.SpanMetrics {
+		sampler = newRecordOnlySampler(sampler)
+	}
+
+	return &switchSampler{sampler: sampler, control: control}
+}
+
+// createExporter creates the exporter of config, in the background with LazyExporter.
+func createExporter(config TracerConfig) (sdktrace.SpanExporter, error) {
+	if config.LazyExporter {
+		return newLazyExporter(func() (sdktrace.SpanExporter, error) {
+			// OTLP exporters are created without connecting, wait for the collector instead
+			ctx, cancel := context.WithTimeout(context.Background(), defaultBatchTimeout)
+			defer cancel()
+			if err := probeEndpoint(ctx, config); err != nil {
+				return nil, err
+			}
+			return newExporter(config)
+		}), nil
+	}
+	exp, err := newExporter(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateExporter, err)
+	}
+	return exp, nil
+}
+
+// newExporter creates a new span exporter based on the configured exporter type
 func newExporter(config TracerConfig) (sdktrace.SpanExporter, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultBatchTimeout)
 	defer cancel()
@@ -139,6 +372,22 @@ func newExporter(config TracerConfig) (sdktrace.SpanExporter, error) {
 		return newHTTPExporter(ctx, config)
 	}
 
+	if config.ExporterType.IsKafka() {
+		return newKafkaExporter(ctx, config)
+	}
+
+	if config.ExporterType.IsGoogleCloud() {
+		return newGoogleCloudExporter(ctx, config)
+	}
+
+	if config.ExporterType.IsAzureMonitor() {
+		return newAzureMonitorExporter(config)
+	}
+
+	if config.ExporterType.IsStdout() {
+		return newStdoutExporter()
+	}
+
 	return nil, fmt.Errorf("%w: %s", ErrInvalidExporterType, config.ExporterType.String())
 }
 
@@ -152,6 +401,10 @@ func newGRPCExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExp
 		options = append(options, otlptracegrpc.WithInsecure())
 	}
 
+	if len(config.Headers) > 0 {
+		options = append(options, otlptracegrpc.WithHeaders(config.Headers))
+	}
+
 	exporter, err := otlptracegrpc.New(ctx, options...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrCreateGRPCExporter, err)
@@ -162,6 +415,10 @@ func newGRPCExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExp
 
 // newHTTPExporter creates a new OTLP HTTP exporter
 func newHTTPExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExporter, error) {
+	if config.HTTPJSON {
+		return newHTTPJSONExporter(ctx, config)
+	}
+
 	options := []otlptracehttp.Option{
 		otlptracehttp.WithEndpoint(config.TraceURL),
 	}
@@ -170,6 +427,14 @@ func newHTTPExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExp
 		options = append(options, otlptracehttp.WithInsecure())
 	}
 
+	if len(config.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(config.Headers))
+	}
+
+	if config.URLPath != "" {
+		options = append(options, otlptracehttp.WithURLPath(config.URLPath))
+	}
+
 	exporter, err := otlptracehttp.New(ctx, options...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrCreateHTTPExporter, err)
@@ -178,7 +443,18 @@ func newHTTPExporter(ctx context.Context, config TracerConfig) (sdktrace.SpanExp
 	return exporter, nil
 }
 
+// newStdoutExporter creates an exporter writing pretty-printed spans to standard output
+func newStdoutExporter() (sdktrace.SpanExporter, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateStdoutExporter, err)
+	}
+
+	return exporter, nil
+}
+
 // Span starts a new span with the given name and options.
+// Before Initialize it returns the span from ctx (a no-op span), or panics in strict mode.
 func Span(
 	ctx context.Context,
 	name string,
@@ -188,17 +464,94 @@ func Span(
 	defer globalMutex.RUnlock()
 
 	if !initialized || globalTracer == nil {
+		if strictMode {
+			panic(notInitializedError(name))
+		}
 		// Return a no-op span if not initialized
 		return ctx, oteltrace.SpanFromContext(ctx)
 	}
 
+	if tracingDisabled.Load() || isSuppressed(ctx) {
+		//nolint:spancheck // span is returned to caller who is responsible for ending it
+		return noopTracer.Start(ctx, name, opts...)
+	}
+
+	if globalCodeAttributes {
+		opts = append(slices.Clip(opts), oteltrace.WithAttributes(CodeAttributes()...))
+	}
+
+	ctx, span := globalTracer.Start(ctx, name, opts...)
+	if globalSpanTracker != nil {
+		return globalSpanTracker.track(ctx, name, span)
+	}
+
 	//nolint:spancheck // span is returned to caller who is responsible for ending it
-	return globalTracer.Start(ctx, name, opts...)
+	return ctx, span
+}
+
+// SpanWithAttrs is like Span with attributes set at start, so samplers can base their
+// decision on them. Attributes set after start are not visible to the sampler.
+func SpanWithAttrs(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	//nolint:spancheck // span is returned to caller who is responsible for ending it
+	return Span(ctx, name, oteltrace.WithAttributes(attrs...))
+}
+
+// ForceFlush exports all ended spans that have not been exported yet.
+func ForceFlush(ctx context.Context) error {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+
+	var provider flusher = globalTracerProvider
+	if globalExternalProvider != nil {
+		external, ok := globalExternalProvider.(flusher)
+		if !ok {
+			return nil
+		}
+		provider = external
+	}
+	if err := provider.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrTracerProviderFlush, err)
+	}
+	return nil
 }
 
 // Shutdown gracefully shuts down the tracer provider and exporter.
-// Should be called during application shutdown.
+// Should be called during application shutdown. When ctx has no deadline,
+// TracerConfig.ShutdownTimeout applies.
 func Shutdown(ctx context.Context) error {
+	globalMutex.RLock()
+	isInitialized, timeout := initialized, globalConfig.ShutdownTimeout
+	globalMutex.RUnlock()
+
+	if !isInitialized {
+		return ErrNotInitialized
+	}
+
+	if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Hooks run without the lock so they can still use Span and ForceFlush
+	hooksErr := globalShutdownHooks.run(ctx)
+
+	// Spans ended by EndAsync are exported only if they end before the provider shuts down
+	globalAsyncEnder.wait(ctx)
+
+	err := shutdown(ctx)
+	if hooksErr != nil {
+		return errors.Join(hooksErr, err)
+	}
+	return err
+}
+
+// shutdown shuts down the tracer provider and exporter and resets the global state.
+func shutdown(ctx context.Context) error {
 	globalMutex.Lock()
 	defer globalMutex.Unlock()
 
@@ -209,6 +562,23 @@ func Shutdown(ctx context.Context) error {
 	logger := slog.Default()
 	var shutdownErr error
 
+	if globalSpanWatchdog != nil {
+		globalSpanWatchdog.shutdown()
+		releaseSpanTracker()
+	}
+
+	if openTracingBridgeInstalled {
+		uninstallOpenTracingBridge()
+	}
+
+	if globalExternalProvider != nil {
+		releaseExternalProvider()
+	}
+
+	if globalPipelineMetrics != nil {
+		globalPipelineMetrics.unregister()
+	}
+
 	if globalTracerProvider != nil {
 		if err := globalTracerProvider.Shutdown(ctx); err != nil {
 			logger.ErrorContext(ctx, "Failed to shutdown tracer provider", "error", err)
@@ -233,11 +603,59 @@ func Shutdown(ctx context.Context) error {
 	globalTracer = nil
 	globalTracerProvider = nil
 	globalExporter = nil
+	globalExternalProvider = nil
+	globalPropagationOnly = false
+	globalResource = nil
+	globalSpanWatchdog = nil
+	globalSamplerStats = nil
+	globalTraceAttributes = nil
+	globalDebugHeader = ""
+	globalSemconvStability = SemconvStability{}
+	globalStatementSanitizer = statementSanitizer{}
+	globalRecordErrorChain = false
+	globalCodeAttributes = false
+	globalConfig = TracerConfig{}
+	globalPipelineMetrics = nil
+	globalSamplingControl = nil
+	globalReload = nil
+	tracingDisabled.Store(false)
+	openTracingBridgeInstalled = false
 	initialized = false
 
 	return shutdownErr
 }
 
+// ShutdownWithTimeout is like Shutdown with a context that expires after timeout.
+func ShutdownWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return Shutdown(ctx)
+}
+
+// Provider returns the tracer provider Span creates spans with, for instrumentation libraries
+// that take a provider. Before Initialize it returns a no-op provider.
+func Provider() oteltrace.TracerProvider {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	switch {
+	case globalExternalProvider != nil:
+		return globalExternalProvider
+	case globalTracerProvider != nil:
+		return globalTracerProvider
+	default:
+		return noop.NewTracerProvider()
+	}
+}
+
+// Resource returns the resource describing the service. It is nil before Initialize and
+// after InitializeWithProvider, as the resource of an external provider is not known.
+func Resource() *resource.Resource {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return globalResource
+}
+
 // IsInitialized returns true if the tracer has been initialized.
 func IsInitialized() bool {
 	globalMutex.RLock()
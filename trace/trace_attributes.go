@@ -0,0 +1,120 @@
+package trace
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SetTraceAttribute sets an attribute on the active span and on every span started after
+// it in the same trace within this process, e.g. a user.id discovered mid-request.
+// It is a no-op when the tracer is not initialized or the active span is not recording.
+func SetTraceAttribute(ctx context.Context, key string, value attribute.Value) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	kv := attribute.KeyValue{Key: attribute.Key(key), Value: value}
+	span.SetAttributes(kv)
+
+	globalMutex.RLock()
+	registry := globalTraceAttributes
+	globalMutex.RUnlock()
+
+	if registry != nil {
+		registry.set(span.SpanContext().TraceID(), kv)
+	}
+}
+
+// traceAttributes holds the trace-scoped attributes per trace and applies them to spans
+// as they start. A trace is forgotten when its local root span ends.
+type traceAttributes struct {
+	mu     sync.Mutex
+	traces map[oteltrace.TraceID]*list.Element
+	order  *list.List // oldest first, bounds memory when root spans never end
+}
+
+type traceAttributeSet struct {
+	traceID oteltrace.TraceID
+	attrs   []attribute.KeyValue
+}
+
+var _ sdktrace.SpanProcessor = (*traceAttributes)(nil)
+
+func newTraceAttributes() *traceAttributes {
+	return &traceAttributes{
+		traces: make(map[oteltrace.TraceID]*list.Element),
+		order:  list.New(),
+	}
+}
+
+func (t *traceAttributes) set(traceID oteltrace.TraceID, kv attribute.KeyValue) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elem, ok := t.traces[traceID]
+	if !ok {
+		if t.order.Len() >= defaultMaxBufferedTraces {
+			oldest := t.order.Front()
+			t.order.Remove(oldest)
+			delete(t.traces, oldest.Value.(*traceAttributeSet).traceID)
+		}
+		elem = t.order.PushBack(&traceAttributeSet{traceID: traceID})
+		t.traces[traceID] = elem
+	}
+
+	set := elem.Value.(*traceAttributeSet)
+	for i, existing := range set.attrs {
+		if existing.Key == kv.Key {
+			set.attrs[i] = kv
+			return
+		}
+	}
+	set.attrs = append(set.attrs, kv)
+}
+
+func (t *traceAttributes) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	t.mu.Lock()
+	elem, ok := t.traces[s.SpanContext().TraceID()]
+	var attrs []attribute.KeyValue
+	if ok {
+		attrs = append(attrs, elem.Value.(*traceAttributeSet).attrs...)
+	}
+	t.mu.Unlock()
+
+	if len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+}
+
+func (t *traceAttributes) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Parent().IsValid() && !s.Parent().IsRemote() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, ok := t.traces[s.SpanContext().TraceID()]; ok {
+		t.order.Remove(elem)
+		delete(t.traces, s.SpanContext().TraceID())
+	}
+}
+
+func (t *traceAttributes) Shutdown(context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clear(t.traces)
+	t.order.Init()
+	return nil
+}
+
+func (t *traceAttributes) ForceFlush(context.Context) error {
+	return nil
+}
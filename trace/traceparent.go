@@ -0,0 +1,34 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const traceParentHeader = "traceparent"
+
+// TraceParentFromContext serializes the span context of ctx as a W3C traceparent string
+// (e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"), for channels the
+// propagator API does not cover such as database rows, job payloads or webhooks.
+// Returns an empty string when ctx has no valid span context.
+func TraceParentFromContext(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentHeader]
+}
+
+// ContextFromTraceParent returns a copy of ctx with the remote span context parsed from a
+// W3C traceparent string, so spans started from it continue that trace.
+func ContextFromTraceParent(ctx context.Context, traceParent string) (context.Context, error) {
+	carrier := propagation.MapCarrier{traceParentHeader: traceParent}
+	extracted := propagation.TraceContext{}.Extract(context.Background(), carrier)
+
+	sc := oteltrace.SpanContextFromContext(extracted)
+	if !sc.IsValid() {
+		return ctx, fmt.Errorf("%w: %q", ErrInvalidTraceParent, traceParent)
+	}
+	return oteltrace.ContextWithRemoteSpanContext(ctx, sc), nil
+}
@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestContextFromTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		wantErr     bool
+		wantSampled bool
+	}{
+		{
+			name:        "sampled",
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantSampled: true,
+		},
+		{
+			name:        "not sampled",
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+		},
+		{
+			name:        "future version with extra fields",
+			traceParent: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			wantSampled: true,
+		},
+		{name: "empty", traceParent: "", wantErr: true},
+		{name: "invalid version", traceParent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantErr: true},
+		{name: "zero trace ID", traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wantErr: true},
+		{name: "zero span ID", traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", wantErr: true},
+		{name: "upper case", traceParent: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-01", wantErr: true},
+		{name: "truncated", traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := ContextFromTraceParent(context.Background(), tt.traceParent)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTraceParent) {
+					t.Fatalf("err = %v, want %v", err, ErrInvalidTraceParent)
+				}
+				if oteltrace.SpanContextFromContext(ctx).IsValid() {
+					t.Error("context has a span context")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sc := oteltrace.SpanContextFromContext(ctx)
+			if !sc.IsRemote() {
+				t.Error("span context is not remote")
+			}
+			if sc.IsSampled() != tt.wantSampled {
+				t.Errorf("sampled = %v, want %v", sc.IsSampled(), tt.wantSampled)
+			}
+			if got := sc.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("trace ID = %s", got)
+			}
+		})
+	}
+}
+
+func TestTraceParentFromContext(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	if got := TraceParentFromContext(context.Background()); got != "" {
+		t.Errorf("TraceParentFromContext without span = %q, want empty", got)
+	}
+
+	ctx, err := ContextFromTraceParent(context.Background(), traceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := TraceParentFromContext(ctx); got != traceParent {
+		t.Errorf("TraceParentFromContext = %q, want %q", got, traceParent)
+	}
+}
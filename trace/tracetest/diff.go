@@ -0,0 +1,126 @@
+package tracetest
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	missingAttribute = "<missing>"
+	indent           = "  "
+)
+
+// Diff compares two lists of span trees by span name, hierarchy and the attributes listed
+// in expected; other attributes of actual are ignored. It returns "" when they match, or
+// the trees with missing spans prefixed by "-", unexpected spans by "+" and attribute
+// mismatches by "!":
+//
+//	if diff := tracetest.Diff(expected, recorder.Tree()); diff != "" {
+//		t.Errorf("span trees differ (-expected +actual):\n%s", diff)
+//	}
+func Diff(expected, actual []SpanTree) string {
+	var b strings.Builder
+	if !diffTrees(&b, expected, actual, 0) {
+		return ""
+	}
+	return b.String()
+}
+
+// diffTrees writes the aligned trees and reports whether they differ. Siblings are aligned
+// on the longest common subsequence of names, so an extra or missing span does not shift
+// the comparison of the spans after it.
+func diffTrees(b *strings.Builder, expected, actual []SpanTree, depth int) bool {
+	differs := false
+	i, j := 0, 0
+	for _, pair := range alignByName(expected, actual) {
+		for ; i < pair[0]; i++ {
+			writeTree(b, "-", expected[i], depth)
+			differs = true
+		}
+		for ; j < pair[1]; j++ {
+			writeTree(b, "+", actual[j], depth)
+			differs = true
+		}
+		if diffNode(b, expected[i], actual[j], depth) {
+			differs = true
+		}
+		i, j = i+1, j+1
+	}
+	for ; i < len(expected); i++ {
+		writeTree(b, "-", expected[i], depth)
+		differs = true
+	}
+	for ; j < len(actual); j++ {
+		writeTree(b, "+", actual[j], depth)
+		differs = true
+	}
+	return differs
+}
+
+func diffNode(b *strings.Builder, expected, actual SpanTree, depth int) bool {
+	writeLine(b, " ", expected.Name, depth)
+
+	differs := false
+	for _, key := range slices.Sorted(maps.Keys(expected.Attributes)) {
+		want := expected.Attributes[key]
+		got, ok := actual.Attributes[key]
+		if !ok {
+			got = missingAttribute
+		} else if got == want {
+			continue
+		} else {
+			got = strconv.Quote(got)
+		}
+		writeLine(b, "!", key+": expected "+strconv.Quote(want)+", got "+got, depth+1)
+		differs = true
+	}
+
+	if diffTrees(b, expected.Children, actual.Children, depth+1) {
+		differs = true
+	}
+	return differs
+}
+
+// alignByName returns the index pairs of the longest common subsequence of sibling names.
+func alignByName(expected, actual []SpanTree) [][2]int {
+	lengths := make([][]int, len(expected)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i].Name == actual[j].Name {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var pairs [][2]int
+	for i, j := 0, 0; i < len(expected) && j < len(actual); {
+		switch {
+		case expected[i].Name == actual[j].Name:
+			pairs = append(pairs, [2]int{i, j})
+			i, j = i+1, j+1
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+func writeTree(b *strings.Builder, marker string, tree SpanTree, depth int) {
+	writeLine(b, marker, tree.Name, depth)
+	for _, child := range tree.Children {
+		writeTree(b, marker, child, depth+1)
+	}
+}
+
+func writeLine(b *strings.Builder, marker, text string, depth int) {
+	b.WriteString(marker + " " + strings.Repeat(indent, depth) + text + "\n")
+}
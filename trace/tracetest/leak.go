@@ -0,0 +1,28 @@
+// Package tracetest provides helpers for testing code instrumented with the trace package.
+package tracetest
+
+import (
+	"testing"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+)
+
+// VerifyNoLeakedSpans fails the test if spans created via trace.Span after this call
+// are still open when the test and its deferred calls have finished.
+// Call it at the beginning of a test; it is not reliable for parallel tests since
+// span tracking is global.
+//
+//	func TestProcessOrder(t *testing.T) {
+//		tracetest.VerifyNoLeakedSpans(t)
+//		...
+//	}
+func VerifyNoLeakedSpans(t testing.TB) {
+	t.Helper()
+
+	stop := trace.TrackSpans()
+	t.Cleanup(func() {
+		for _, span := range stop() {
+			t.Errorf("span %q was never ended, started at:\n%s", span.Name, span.Stack)
+		}
+	})
+}
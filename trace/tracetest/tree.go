@@ -0,0 +1,96 @@
+package tracetest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SpanTree is the structure of a span and its descendants, as compared by Diff.
+type SpanTree struct {
+	Name string
+	// Attributes holds the attribute values formatted with attribute.Value.Emit. In an
+	// expected tree, only the listed attributes are compared.
+	Attributes map[string]string
+	Children   []SpanTree
+}
+
+// BuildTree builds the span trees of spans, ordering siblings by start time. Spans whose
+// parent is not among spans become roots.
+func BuildTree(spans []sdktrace.ReadOnlySpan) []SpanTree {
+	ordered := slices.Clone(spans)
+	slices.SortStableFunc(ordered, func(a, b sdktrace.ReadOnlySpan) int {
+		return a.StartTime().Compare(b.StartTime())
+	})
+
+	present := make(map[oteltrace.SpanID]bool, len(ordered))
+	for _, span := range ordered {
+		present[span.SpanContext().SpanID()] = true
+	}
+
+	children := make(map[oteltrace.SpanID][]sdktrace.ReadOnlySpan)
+	var roots []sdktrace.ReadOnlySpan
+	for _, span := range ordered {
+		parentID := span.Parent().SpanID()
+		if span.Parent().IsValid() && present[parentID] && parentID != span.SpanContext().SpanID() {
+			children[parentID] = append(children[parentID], span)
+		} else {
+			roots = append(roots, span)
+		}
+	}
+
+	return buildSubtrees(roots, children)
+}
+
+func buildSubtrees(spans []sdktrace.ReadOnlySpan, children map[oteltrace.SpanID][]sdktrace.ReadOnlySpan) []SpanTree {
+	trees := make([]SpanTree, 0, len(spans))
+	for _, span := range spans {
+		attrs := make(map[string]string, len(span.Attributes()))
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+		}
+		trees = append(trees, SpanTree{
+			Name:       span.Name(),
+			Attributes: attrs,
+			Children:   buildSubtrees(children[span.SpanContext().SpanID()], children),
+		})
+	}
+	return trees
+}
+
+// Recorder collects the spans ended through the global tracer provider.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+// RecordSpans records the spans ended until the test finishes. The tracer must be
+// initialized with TraceEnabled and a sample rate that keeps the spans of interest.
+// Recording is global, so avoid it in parallel tests.
+func RecordSpans(t testing.TB) *Recorder {
+	t.Helper()
+
+	recorder := &Recorder{}
+	t.Cleanup(trace.OnSpanEnd(func(span sdktrace.ReadOnlySpan) {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		recorder.spans = append(recorder.spans, span)
+	}))
+	return recorder
+}
+
+// Spans returns the spans ended so far, in end order.
+func (r *Recorder) Spans() []sdktrace.ReadOnlySpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.spans)
+}
+
+// Tree returns the trees of the spans ended so far.
+func (r *Recorder) Tree() []SpanTree {
+	return BuildTree(r.Spans())
+}
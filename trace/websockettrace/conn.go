@@ -0,0 +1,290 @@
+// Package websockettrace traces gorilla/websocket connections through the global tracer
+// configured by the trace package: a span for the upgrade handshake, a span for the
+// lifetime of the connection, and events or spans for the messages.
+package websockettrace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cristiano-pacheco/go-otel/trace"
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	upgradeSpanName    = "WebSocket upgrade"
+	connectionSpanName = "WebSocket connection"
+	sendSpanName       = "WebSocket send"
+	receiveSpanName    = "WebSocket receive"
+
+	messageSentEvent     = "websocket.message.sent"
+	messageReceivedEvent = "websocket.message.received"
+
+	subprotocolKey      = attribute.Key("websocket.subprotocol")
+	messageTypeKey      = attribute.Key("websocket.message.type")
+	messageSizeKey      = attribute.Key("websocket.message.size")
+	closeCodeKey        = attribute.Key("websocket.close.code")
+	messagesSentKey     = attribute.Key("websocket.messages.sent")
+	messagesReceivedKey = attribute.Key("websocket.messages.received")
+	bytesSentKey        = attribute.Key("websocket.bytes.sent")
+	bytesReceivedKey    = attribute.Key("websocket.bytes.received")
+)
+
+// MessageMode selects how messages are traced.
+type MessageMode int
+
+const (
+	// MessageEvents adds an event per message to the connection span. The SDK keeps at
+	// most 128 events per span, later ones are only counted.
+	MessageEvents MessageMode = iota
+	// MessageSpans creates a producer span per sent message and a consumer span per
+	// received message, as children of the connection span.
+	MessageSpans
+	// MessageNone only counts messages on the connection span.
+	MessageNone
+)
+
+// Config configures the connection instrumentation.
+type Config struct {
+	Messages MessageMode
+}
+
+// Conn is a websocket.Conn whose messages are traced. ReadMessage and WriteMessage are
+// traced, NextReader, NextWriter and the JSON helpers of the embedded connection are not.
+type Conn struct {
+	*websocket.Conn
+
+	ctx    context.Context
+	span   oteltrace.Span
+	config Config
+
+	messagesSent     atomic.Int64
+	messagesReceived atomic.Int64
+	bytesSent        atomic.Int64
+	bytesReceived    atomic.Int64
+	endOnce          sync.Once
+}
+
+// Upgrade upgrades the request like upgrader.Upgrade within a span for the handshake.
+// The trace context is taken from the request context, or extracted from the request
+// headers when it has no span, e.g. without the httptrace middleware.
+func Upgrade(
+	upgrader *websocket.Upgrader,
+	w http.ResponseWriter,
+	r *http.Request,
+	responseHeader http.Header,
+	config Config,
+) (*Conn, error) {
+	ctx := r.Context()
+	kind := oteltrace.SpanKindInternal
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+		kind = oteltrace.SpanKindServer
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.URLPath(r.URL.Path),
+		semconv.NetworkPeerAddress(r.RemoteAddr),
+	}
+
+	_, span := trace.Span(ctx, upgradeSpanName, oteltrace.WithSpanKind(kind), oteltrace.WithAttributes(attrs...))
+	defer span.End()
+
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return newConn(ctx, conn, attrs, config), nil
+}
+
+// Dial connects like dialer.DialContext within a client span for the handshake and
+// injects the trace context into the handshake request headers.
+func Dial(
+	ctx context.Context,
+	dialer *websocket.Dialer,
+	urlStr string,
+	requestHeader http.Header,
+	config Config,
+) (*Conn, *http.Response, error) {
+	attrs := []attribute.KeyValue{semconv.URLFull(redactURL(urlStr))}
+
+	dialCtx, span := trace.Span(
+		ctx,
+		upgradeSpanName,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	header := requestHeader.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(dialCtx, propagation.HeaderCarrier(header))
+
+	conn, resp, err := dialer.DialContext(dialCtx, urlStr, header)
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, resp, err
+	}
+
+	return newConn(ctx, conn, attrs, config), resp, nil
+}
+
+func newConn(ctx context.Context, conn *websocket.Conn, attrs []attribute.KeyValue, config Config) *Conn {
+	if subprotocol := conn.Subprotocol(); subprotocol != "" {
+		attrs = append(attrs, subprotocolKey.String(subprotocol))
+	}
+	ctx, span := trace.Span(ctx, connectionSpanName, oteltrace.WithAttributes(attrs...))
+	return &Conn{Conn: conn, ctx: ctx, span: span, config: config}
+}
+
+// Context returns a context carrying the connection span, to trace the handling of messages.
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// ReadMessage reads a message like websocket.Conn.ReadMessage. A read error means the
+// connection is done, so it ends the connection span, recording the close code.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	messageType, p, err := c.Conn.ReadMessage()
+	if err != nil {
+		c.end(err)
+		return messageType, p, err
+	}
+
+	c.messagesReceived.Add(1)
+	c.bytesReceived.Add(int64(len(p)))
+
+	attrs := messageAttributes(messageType, len(p))
+	switch c.config.Messages {
+	case MessageEvents:
+		c.span.AddEvent(messageReceivedEvent, oteltrace.WithAttributes(attrs...))
+	case MessageSpans:
+		// the span marks the receipt, the wait for the message is not part of it
+		_, span := trace.Span(c.ctx, receiveSpanName,
+			oteltrace.WithSpanKind(oteltrace.SpanKindConsumer),
+			oteltrace.WithAttributes(attrs...),
+		)
+		span.End()
+	case MessageNone:
+	}
+	return messageType, p, nil
+}
+
+// WriteMessage writes a message like websocket.Conn.WriteMessage.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	attrs := messageAttributes(messageType, len(data))
+
+	span := c.span
+	switch c.config.Messages {
+	case MessageSpans:
+		_, span = trace.Span(c.ctx, sendSpanName,
+			oteltrace.WithSpanKind(oteltrace.SpanKindProducer),
+			oteltrace.WithAttributes(attrs...),
+		)
+		defer span.End()
+	case MessageEvents, MessageNone:
+	}
+
+	if err := c.Conn.WriteMessage(messageType, data); err != nil {
+		if c.config.Messages != MessageNone {
+			span.RecordError(err, oteltrace.WithAttributes(attrs...))
+		}
+		if c.config.Messages == MessageSpans {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+
+	c.messagesSent.Add(1)
+	c.bytesSent.Add(int64(len(data)))
+	if c.config.Messages == MessageEvents {
+		c.span.AddEvent(messageSentEvent, oteltrace.WithAttributes(attrs...))
+	}
+	return nil
+}
+
+// Close closes the connection and ends the connection span.
+func (c *Conn) Close() error {
+	c.end(nil)
+	return c.Conn.Close()
+}
+
+// end ends the connection span once, with the message counters and the close code of err.
+func (c *Conn) end(err error) {
+	c.endOnce.Do(func() {
+		c.span.SetAttributes(
+			messagesSentKey.Int64(c.messagesSent.Load()),
+			messagesReceivedKey.Int64(c.messagesReceived.Load()),
+			bytesSentKey.Int64(c.bytesSent.Load()),
+			bytesReceivedKey.Int64(c.bytesReceived.Load()),
+		)
+
+		var closeErr *websocket.CloseError
+		switch {
+		case errors.As(err, &closeErr):
+			c.span.SetAttributes(closeCodeKey.Int(closeErr.Code))
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.span.SetStatus(codes.Error, closeErr.Error())
+			}
+		case err != nil:
+			c.span.RecordError(err)
+			c.span.SetStatus(codes.Error, err.Error())
+		}
+		c.span.End()
+	})
+}
+
+// redactURL drops credentials and the query, which often carries tokens for WebSockets.
+func redactURL(urlStr string) string {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+func messageAttributes(messageType, size int) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		messageTypeKey.String(messageTypeName(messageType)),
+		messageSizeKey.Int(size),
+	}
+}
+
+func messageTypeName(messageType int) string {
+	switch messageType {
+	case websocket.TextMessage:
+		return "text"
+	case websocket.BinaryMessage:
+		return "binary"
+	case websocket.CloseMessage:
+		return "close"
+	case websocket.PingMessage:
+		return "ping"
+	case websocket.PongMessage:
+		return "pong"
+	default:
+		return "unknown"
+	}
+}